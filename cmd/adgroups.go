@@ -197,10 +197,6 @@ func runAdGroupsCreate(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if err := checkBidLimit(agBid); err != nil {
-		return err
-	}
-
 	autoKW := agAutoKW == "true"
 	adgroup := &models.AdGroup{
 		Name:                   agName,
@@ -219,6 +215,12 @@ func runAdGroupsCreate(cmd *cobra.Command, args []string) error {
 	if agEndTime != "" {
 		adgroup.EndTime = agEndTime
 	}
+	if targetingChanged(cmd) {
+		adgroup.TargetingDimensions = &models.TargetingDimensions{}
+		if err := applyTargeting(cmd, client, agCampaignID, adgroup.TargetingDimensions); err != nil {
+			return err
+		}
+	}
 
 	svc := services.NewAdGroupService(client)
 	created, err := svc.Create(agCampaignID, adgroup)
@@ -254,9 +256,6 @@ func runAdGroupsUpdate(cmd *cobra.Command, args []string) error {
 			return err
 		}
 		if cmd.Flags().Changed("default-bid") {
-			if err := checkBidLimit(agBid); err != nil {
-				return err
-			}
 			update.DefaultBidAmount = &models.Money{Amount: agBid, Currency: currency}
 			hasUpdate = true
 		}
@@ -283,11 +282,31 @@ func runAdGroupsUpdate(cmd *cobra.Command, args []string) error {
 		hasUpdate = true
 	}
 
+	svc := services.NewAdGroupService(client)
+	var before *models.AdGroup
+	if targetingChanged(cmd) || (dryRun && hasUpdate) {
+		before, err = svc.Get(agCampaignID, id)
+		if err != nil {
+			return fmt.Errorf("getting ad group: %w", err)
+		}
+	}
+	if targetingChanged(cmd) {
+		td := currentTargeting(before)
+		if err := applyTargeting(cmd, client, agCampaignID, &td); err != nil {
+			return err
+		}
+		update.TargetingDimensions = &td
+		hasUpdate = true
+	}
+
 	if !hasUpdate {
 		return fmt.Errorf("no update flags provided")
 	}
 
-	svc := services.NewAdGroupService(client)
+	if dryRun {
+		previewUpdate(fmt.Sprintf("Ad group %d", id), before, update)
+	}
+
 	updated, err := svc.Update(agCampaignID, id, update)
 	if err != nil {
 		return fmt.Errorf("updating ad group: %w", err)
@@ -309,6 +328,27 @@ func runAdGroupsDelete(cmd *cobra.Command, args []string) error {
 	}
 
 	svc := services.NewAdGroupService(client)
+	adgroup, err := svc.Get(agCampaignID, id)
+	if err != nil {
+		return fmt.Errorf("getting ad group: %w", err)
+	}
+	keywords, err := services.NewKeywordService(client).FindAll(agCampaignID, id, models.NewSelector(1000, 0))
+	if err != nil {
+		return fmt.Errorf("fetching keywords: %w", err)
+	}
+	active := 0
+	for _, kw := range keywords {
+		if !kw.Deleted {
+			active++
+		}
+	}
+	if err := confirmDestructive("delete an ad group", []string{
+		fmt.Sprintf("Ad group %d %q (status: %s) in campaign %d", adgroup.ID, adgroup.Name, adgroup.Status, agCampaignID),
+		fmt.Sprintf("Also deletes %d keyword(s)", active),
+	}); err != nil {
+		return err
+	}
+
 	if err := svc.Delete(agCampaignID, id); err != nil {
 		return fmt.Errorf("deleting ad group: %w", err)
 	}
@@ -0,0 +1,299 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/trebuhs/asa-cli/internal/models"
+	"github.com/trebuhs/asa-cli/internal/output"
+	"github.com/trebuhs/asa-cli/internal/services"
+)
+
+var adsCmd = &cobra.Command{
+	Use:   "ads",
+	Short: "Manage ads (creatives attached to an ad group)",
+}
+
+var adsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List ads in an ad group",
+	RunE:  runAdsList,
+}
+
+var adsGetCmd = &cobra.Command{
+	Use:   "get <id>",
+	Short: "Get an ad by ID",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runAdsGet,
+}
+
+var adsFindCmd = &cobra.Command{
+	Use:   "find",
+	Short: "Find ads in a campaign with filters",
+	RunE:  runAdsFind,
+}
+
+var adsCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create an ad from a creative",
+	RunE:  runAdsCreate,
+}
+
+var adsUpdateCmd = &cobra.Command{
+	Use:   "update <id>",
+	Short: "Update an ad",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runAdsUpdate,
+}
+
+var adsDeleteCmd = &cobra.Command{
+	Use:   "delete <id>",
+	Short: "Delete an ad",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runAdsDelete,
+}
+
+var (
+	adCampaignID int64
+	adAdGroupID  int64
+	adLimit      int
+	adOffset     int
+	adFilters    []string
+	adSorts      []string
+	adAll        bool
+	adName       string
+	adCreativeID int64
+	adPageID     string
+	adStatus     string
+)
+
+func init() {
+	for _, cmd := range []*cobra.Command{adsListCmd, adsGetCmd, adsFindCmd, adsCreateCmd, adsUpdateCmd, adsDeleteCmd} {
+		cmd.Flags().Int64Var(&adCampaignID, "campaign-id", 0, "Campaign ID (required)")
+		cmd.MarkFlagRequired("campaign-id")
+	}
+	for _, cmd := range []*cobra.Command{adsListCmd, adsGetCmd, adsCreateCmd, adsUpdateCmd, adsDeleteCmd} {
+		cmd.Flags().Int64Var(&adAdGroupID, "adgroup-id", 0, "Ad group ID (required)")
+		cmd.MarkFlagRequired("adgroup-id")
+	}
+
+	// list
+	adsListCmd.Flags().IntVar(&adLimit, "limit", 20, "Number of results")
+	adsListCmd.Flags().IntVar(&adOffset, "offset", 0, "Results offset")
+
+	// find
+	adsFindCmd.Flags().Int64Var(&adAdGroupID, "adgroup-id", 0, "Only ads in this ad group")
+	adsFindCmd.Flags().StringSliceVar(&adFilters, "filter", nil, `Filter conditions`)
+	adsFindCmd.Flags().StringSliceVar(&adSorts, "sort", nil, `Sort order`)
+	adsFindCmd.Flags().IntVar(&adLimit, "limit", 20, "Number of results")
+	adsFindCmd.Flags().IntVar(&adOffset, "offset", 0, "Results offset")
+	adsFindCmd.Flags().BoolVar(&adAll, "all", false, "Fetch all pages")
+
+	// create
+	adsCreateCmd.Flags().StringVar(&adName, "name", "", "Ad name (required)")
+	adsCreateCmd.Flags().Int64Var(&adCreativeID, "creative-id", 0, "Creative ID")
+	adsCreateCmd.Flags().StringVar(&adPageID, "product-page-id", "", "Custom product page ID (creates or reuses its creative)")
+	adsCreateCmd.Flags().StringVar(&adStatus, "status", "ENABLED", "Status (ENABLED/PAUSED)")
+	adsCreateCmd.MarkFlagRequired("name")
+	adsCreateCmd.MarkFlagsOneRequired("creative-id", "product-page-id")
+	adsCreateCmd.MarkFlagsMutuallyExclusive("creative-id", "product-page-id")
+
+	// update
+	adsUpdateCmd.Flags().StringVar(&adName, "name", "", "Ad name")
+	adsUpdateCmd.Flags().StringVar(&adStatus, "status", "", "Status (ENABLED/PAUSED)")
+
+	adsCmd.AddCommand(adsListCmd, adsGetCmd, adsFindCmd, adsCreateCmd, adsUpdateCmd, adsDeleteCmd)
+	rootCmd.AddCommand(adsCmd)
+}
+
+var adColumns = []output.Column{
+	{Header: "ID", Field: "ID", Width: 12},
+	{Header: "NAME", Field: "Name", Width: 30},
+	{Header: "AD GROUP", Field: "AdGroupID", Width: 12},
+	{Header: "CREATIVE", Field: "CreativeID", Width: 12},
+	{Header: "TYPE", Field: "CreativeType", Width: 22},
+	{Header: "STATUS", Field: "Status", Width: 10},
+	{Header: "SERVING", Field: "ServingStatus", Width: 12},
+}
+
+func runAdsList(cmd *cobra.Command, args []string) error {
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	svc := services.NewAdService(client)
+	ads, _, err := svc.List(adCampaignID, adAdGroupID, adLimit, adOffset)
+	if err != nil {
+		return fmt.Errorf("listing ads: %w", err)
+	}
+
+	output.Print(getFormat(), ads, adColumns)
+	return nil
+}
+
+func runAdsGet(cmd *cobra.Command, args []string) error {
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid ad ID: %s", args[0])
+	}
+
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	svc := services.NewAdService(client)
+	ad, err := svc.Get(adCampaignID, adAdGroupID, id)
+	if err != nil {
+		return fmt.Errorf("getting ad: %w", err)
+	}
+
+	output.Print(getFormat(), ad, adColumns)
+	return nil
+}
+
+func runAdsFind(cmd *cobra.Command, args []string) error {
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	selector := models.NewSelector(adLimit, adOffset)
+	selector.Conditions = parseFilters(adFilters)
+	selector.OrderBy = parseSorts(adSorts)
+	if adAdGroupID != 0 {
+		selector.Conditions = append(selector.Conditions, models.Condition{
+			Field:    "adGroupId",
+			Operator: "EQUALS",
+			Values:   []string{strconv.FormatInt(adAdGroupID, 10)},
+		})
+	}
+
+	svc := services.NewAdService(client)
+
+	if adAll {
+		ads, err := svc.FindAll(adCampaignID, selector)
+		if err != nil {
+			return fmt.Errorf("finding ads: %w", err)
+		}
+		output.Print(getFormat(), ads, adColumns)
+	} else {
+		ads, _, err := svc.Find(adCampaignID, selector)
+		if err != nil {
+			return fmt.Errorf("finding ads: %w", err)
+		}
+		output.Print(getFormat(), ads, adColumns)
+	}
+	return nil
+}
+
+func runAdsCreate(cmd *cobra.Command, args []string) error {
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	if adPageID != "" {
+		campaign, err := services.NewCampaignService(client).Get(adCampaignID)
+		if err != nil {
+			return fmt.Errorf("getting campaign: %w", err)
+		}
+		creative, err := productPageCreative(client, campaign.AdamID, adPageID, "", true)
+		if err != nil {
+			return err
+		}
+		adCreativeID = creative.ID
+	}
+
+	ad := &models.Ad{
+		Name:       adName,
+		CreativeID: adCreativeID,
+		Status:     adStatus,
+	}
+
+	svc := services.NewAdService(client)
+	created, err := svc.Create(adCampaignID, adAdGroupID, ad)
+	if err != nil {
+		return fmt.Errorf("creating ad: %w", err)
+	}
+
+	output.Print(getFormat(), created, adColumns)
+	return nil
+}
+
+func runAdsUpdate(cmd *cobra.Command, args []string) error {
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid ad ID: %s", args[0])
+	}
+
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	update := &models.AdUpdate{}
+	hasUpdate := false
+
+	if cmd.Flags().Changed("name") {
+		update.Name = adName
+		hasUpdate = true
+	}
+	if cmd.Flags().Changed("status") {
+		update.Status = adStatus
+		hasUpdate = true
+	}
+
+	if !hasUpdate {
+		return fmt.Errorf("no update flags provided")
+	}
+
+	svc := services.NewAdService(client)
+	if dryRun {
+		before, err := svc.Get(adCampaignID, adAdGroupID, id)
+		if err != nil {
+			return fmt.Errorf("getting ad: %w", err)
+		}
+		previewUpdate(fmt.Sprintf("Ad %d", id), before, update)
+	}
+
+	updated, err := svc.Update(adCampaignID, adAdGroupID, id, update)
+	if err != nil {
+		return fmt.Errorf("updating ad: %w", err)
+	}
+
+	output.Print(getFormat(), updated, adColumns)
+	return nil
+}
+
+func runAdsDelete(cmd *cobra.Command, args []string) error {
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid ad ID: %s", args[0])
+	}
+
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	svc := services.NewAdService(client)
+	ad, err := svc.Get(adCampaignID, adAdGroupID, id)
+	if err != nil {
+		return fmt.Errorf("getting ad: %w", err)
+	}
+	if err := confirmDestructive("delete an ad", []string{
+		fmt.Sprintf("Ad %d %q (status: %s, creative %d) in ad group %d", ad.ID, ad.Name, ad.Status, ad.CreativeID, adAdGroupID),
+	}); err != nil {
+		return err
+	}
+
+	if err := svc.Delete(adCampaignID, adAdGroupID, id); err != nil {
+		return fmt.Errorf("deleting ad: %w", err)
+	}
+
+	fmt.Printf("Ad %d deleted.\n", id)
+	return nil
+}
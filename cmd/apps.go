@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/spf13/cobra"
 	"github.com/trebuhs/asa-cli/internal/output"
@@ -10,7 +11,7 @@ import (
 
 var appsCmd = &cobra.Command{
 	Use:   "apps",
-	Short: "Search App Store apps",
+	Short: "Search App Store apps and their custom product pages",
 }
 
 var appsSearchCmd = &cobra.Command{
@@ -19,11 +20,19 @@ var appsSearchCmd = &cobra.Command{
 	RunE:  runAppsSearch,
 }
 
+var appsProductPagesCmd = &cobra.Command{
+	Use:   "product-pages <adamId>",
+	Short: "List an app's custom product pages, or one page's locales",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runAppsProductPages,
+}
+
 var (
 	appQuery    string
 	appLimit    int
 	appOffset   int
 	appOwnedOnly bool
+	appPageID   string
 )
 
 func init() {
@@ -33,7 +42,11 @@ func init() {
 	appsSearchCmd.Flags().BoolVar(&appOwnedOnly, "owned", false, "Return only owned apps")
 	appsSearchCmd.MarkFlagRequired("query")
 
-	appsCmd.AddCommand(appsSearchCmd)
+	appsProductPagesCmd.Flags().StringVar(&appPageID, "page-id", "", "Show the locales of this product page")
+	appsProductPagesCmd.Flags().IntVar(&appLimit, "limit", 20, "Number of results")
+	appsProductPagesCmd.Flags().IntVar(&appOffset, "offset", 0, "Results offset")
+
+	appsCmd.AddCommand(appsSearchCmd, appsProductPagesCmd)
 	rootCmd.AddCommand(appsCmd)
 }
 
@@ -56,3 +69,43 @@ func runAppsSearch(cmd *cobra.Command, args []string) error {
 	})
 	return nil
 }
+
+func runAppsProductPages(cmd *cobra.Command, args []string) error {
+	adamID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid adam ID: %s", args[0])
+	}
+
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	svc := services.NewAppService(client)
+
+	if appPageID != "" {
+		locales, err := svc.GetProductPageLocales(adamID, appPageID)
+		if err != nil {
+			return fmt.Errorf("getting product page locales: %w", err)
+		}
+		output.Print(getFormat(), locales, []output.Column{
+			{Header: "LANGUAGE", Field: "LanguageCode", Width: 10},
+			{Header: "APP NAME", Field: "AppName", Width: 30},
+			{Header: "SUBTITLE", Field: "SubTitle", Width: 30},
+			{Header: "PROMOTIONAL TEXT", Field: "PromotionalText", Width: 40},
+		})
+		return nil
+	}
+
+	pages, _, err := svc.ListProductPages(adamID, appLimit, appOffset)
+	if err != nil {
+		return fmt.Errorf("listing product pages: %w", err)
+	}
+
+	output.Print(getFormat(), pages, []output.Column{
+		{Header: "PAGE ID", Field: "ID", Width: 38},
+		{Header: "NAME", Field: "Name", Width: 30},
+		{Header: "STATE", Field: "State", Width: 10},
+	})
+	return nil
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/trebuhs/asa-cli/internal/audit"
+	"github.com/trebuhs/asa-cli/internal/output"
+)
+
+var (
+	auditSince  string
+	auditUntil  string
+	auditEntity string
+	auditUser   string
+	auditLimit  int
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Show the local log of changes made through asa-cli",
+	Long: `Every create, update and delete sent to the API is appended to audit.jsonl
+in the config directory, with the profile, org, OS user, command line,
+request and resulting entity IDs. This command lists and filters it.
+
+Examples:
+  asa-cli audit --since 2024-06-01
+  asa-cli audit --entity 123456789
+  asa-cli audit --entity campaigns/123 --user alice -o json`,
+	RunE: runAudit,
+}
+
+func init() {
+	auditCmd.Flags().StringVar(&auditSince, "since", "", "Only changes on or after this date (YYYY-MM-DD or RFC 3339)")
+	auditCmd.Flags().StringVar(&auditUntil, "until", "", "Only changes before this date (YYYY-MM-DD, inclusive, or RFC 3339)")
+	auditCmd.Flags().StringVar(&auditEntity, "entity", "", "Only changes to this entity ID or path fragment (e.g. campaigns/123)")
+	auditCmd.Flags().StringVar(&auditUser, "user", "", "Only changes made by this OS user")
+	auditCmd.Flags().IntVar(&auditLimit, "limit", 0, "Show only the most recent N changes")
+	rootCmd.AddCommand(auditCmd)
+}
+
+// auditRow is the table view of an audit entry.
+type auditRow struct {
+	ID       string
+	Time     string
+	User     string
+	Profile  string
+	OrgID    string
+	Method   string
+	Path     string
+	Status   int
+	Entities string
+	Error    string
+}
+
+func runAudit(cmd *cobra.Command, args []string) error {
+	var q audit.Query
+	var err error
+	if q.Since, err = parseAuditTime(auditSince, false); err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+	if q.Until, err = parseAuditTime(auditUntil, true); err != nil {
+		return fmt.Errorf("invalid --until: %w", err)
+	}
+	q.Entity = auditEntity
+	q.User = auditUser
+
+	entries, err := audit.Read(audit.DefaultPath())
+	if err != nil {
+		return err
+	}
+	entries = audit.Filter(entries, q)
+	if auditLimit > 0 && len(entries) > auditLimit {
+		entries = entries[len(entries)-auditLimit:]
+	}
+
+	if getFormat() == output.FormatJSON {
+		output.Print(getFormat(), entries, nil)
+		return nil
+	}
+
+	rows := make([]auditRow, len(entries))
+	for i, e := range entries {
+		ids := make([]string, len(e.EntityIDs))
+		for j, id := range e.EntityIDs {
+			ids[j] = fmt.Sprintf("%d", id)
+		}
+		rows[i] = auditRow{
+			ID:       e.ID,
+			Time:     e.Time.Local().Format("2006-01-02 15:04:05"),
+			User:     e.User,
+			Profile:  e.Profile,
+			OrgID:    e.OrgID,
+			Method:   e.Method,
+			Path:     e.Path,
+			Status:   e.Status,
+			Entities: strings.Join(ids, ","),
+			Error:    e.Error,
+		}
+	}
+	output.Print(getFormat(), rows, []output.Column{
+		{Header: "CHANGE ID", Field: "ID", Width: 22},
+		{Header: "TIME", Field: "Time", Width: 19},
+		{Header: "USER", Field: "User", Width: 12},
+		{Header: "PROFILE", Field: "Profile", Width: 12},
+		{Header: "ORG ID", Field: "OrgID", Width: 10},
+		{Header: "METHOD", Field: "Method", Width: 6},
+		{Header: "PATH", Field: "Path", Width: 50},
+		{Header: "STATUS", Field: "Status", Width: 6},
+		{Header: "ENTITIES", Field: "Entities", Width: 30},
+		{Header: "ERROR", Field: "Error", Width: 40},
+	})
+	return nil
+}
+
+// parseAuditTime accepts a date in local time or an RFC 3339 timestamp. A bare
+// date used as an upper bound covers the whole day.
+func parseAuditTime(value string, endOfDay bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected YYYY-MM-DD or RFC 3339, got %q", value)
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
@@ -0,0 +1,309 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/trebuhs/asa-cli/internal/api"
+	"github.com/trebuhs/asa-cli/internal/models"
+	"github.com/trebuhs/asa-cli/internal/output"
+	"github.com/trebuhs/asa-cli/internal/services"
+)
+
+var budgetOrdersCmd = &cobra.Command{
+	Use:   "budget-orders",
+	Short: "Manage budget orders (LOC invoicing)",
+}
+
+var budgetOrdersListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List budget orders",
+	RunE:  runBudgetOrdersList,
+}
+
+var budgetOrdersGetCmd = &cobra.Command{
+	Use:   "get <id>",
+	Short: "Get a budget order with its spend and remaining balance",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runBudgetOrdersGet,
+}
+
+var budgetOrdersCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a budget order",
+	RunE:  runBudgetOrdersCreate,
+}
+
+var budgetOrdersUpdateCmd = &cobra.Command{
+	Use:   "update <id>",
+	Short: "Update a budget order",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runBudgetOrdersUpdate,
+}
+
+var (
+	boLimit        int
+	boOffset       int
+	boName         string
+	boBudget       string
+	boStartDate    string
+	boEndDate      string
+	boOrderNumber  string
+	boClientName   string
+	boBuyerName    string
+	boBuyerEmail   string
+	boBillingEmail string
+)
+
+func init() {
+	budgetOrdersListCmd.Flags().IntVar(&boLimit, "limit", 20, "Number of results")
+	budgetOrdersListCmd.Flags().IntVar(&boOffset, "offset", 0, "Results offset")
+
+	for _, cmd := range []*cobra.Command{budgetOrdersCreateCmd, budgetOrdersUpdateCmd} {
+		cmd.Flags().StringVar(&boName, "name", "", "Budget order name")
+		cmd.Flags().StringVar(&boBudget, "budget", "", "Budget amount (e.g. 50000.00)")
+		cmd.Flags().StringVar(&boStartDate, "start-date", "", "Start date (YYYY-MM-DD)")
+		cmd.Flags().StringVar(&boEndDate, "end-date", "", "End date (YYYY-MM-DD)")
+		cmd.Flags().StringVar(&boOrderNumber, "order-number", "", "Purchase order number")
+		cmd.Flags().StringVar(&boClientName, "client-name", "", "Client name")
+		cmd.Flags().StringVar(&boBuyerName, "buyer-name", "", "Primary buyer name")
+		cmd.Flags().StringVar(&boBuyerEmail, "buyer-email", "", "Primary buyer email")
+		cmd.Flags().StringVar(&boBillingEmail, "billing-email", "", "Billing contact email")
+	}
+	for _, f := range []string{"name", "budget", "start-date", "end-date", "order-number", "client-name", "buyer-name", "buyer-email", "billing-email"} {
+		budgetOrdersCreateCmd.MarkFlagRequired(f)
+	}
+
+	budgetOrdersCmd.AddCommand(budgetOrdersListCmd, budgetOrdersGetCmd, budgetOrdersCreateCmd, budgetOrdersUpdateCmd)
+	rootCmd.AddCommand(budgetOrdersCmd)
+}
+
+var budgetOrderColumns = []output.Column{
+	{Header: "ID", Field: "ID", Width: 12},
+	{Header: "NAME", Field: "Name", Width: 25},
+	{Header: "CLIENT", Field: "ClientName", Width: 20},
+	{Header: "ORDER NUMBER", Field: "OrderNumber", Width: 15},
+	{Header: "STATUS", Field: "Status", Width: 10},
+	{Header: "BUDGET", Field: "Budget", Width: 15},
+	{Header: "START", Field: "StartDate", Width: 12},
+	{Header: "END", Field: "EndDate", Width: 12},
+}
+
+// budgetOrderBalance is a budget order with what its campaigns have spent.
+type budgetOrderBalance struct {
+	models.BudgetOrder
+	Spent     float64 `json:"spent"`
+	Remaining float64 `json:"remaining"`
+}
+
+var budgetOrderBalanceColumns = append(append([]output.Column(nil), budgetOrderColumns...),
+	output.Column{Header: "SPENT", Field: "Spent", Width: 12},
+	output.Column{Header: "REMAINING", Field: "Remaining", Width: 12},
+)
+
+func runBudgetOrdersList(cmd *cobra.Command, args []string) error {
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	orders, _, err := services.NewBudgetOrderService(client).List(boLimit, boOffset)
+	if err != nil {
+		return fmt.Errorf("listing budget orders: %w", err)
+	}
+
+	output.Print(getFormat(), orders, budgetOrderColumns)
+	return nil
+}
+
+func runBudgetOrdersGet(cmd *cobra.Command, args []string) error {
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid budget order ID: %s", args[0])
+	}
+
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	order, err := services.NewBudgetOrderService(client).Get(id)
+	if err != nil {
+		return fmt.Errorf("getting budget order: %w", err)
+	}
+	campaigns, err := services.NewCampaignService(client).FindAll(models.NewSelector(1000, 0))
+	if err != nil {
+		return fmt.Errorf("finding campaigns: %w", err)
+	}
+	balance, err := budgetOrderBalanceOf(client, *order, campaigns)
+	if err != nil {
+		return err
+	}
+
+	output.Print(getFormat(), balance, budgetOrderBalanceColumns)
+	return nil
+}
+
+func runBudgetOrdersCreate(cmd *cobra.Command, args []string) error {
+	if err := checkBudgetOrderDates(); err != nil {
+		return err
+	}
+
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	orgID, err := requestOrgID()
+	if err != nil {
+		return err
+	}
+	currency, err := resolveOrgCurrency(client)
+	if err != nil {
+		return err
+	}
+
+	order := &models.BudgetOrder{
+		Name:              boName,
+		Budget:            &models.Money{Amount: boBudget, Currency: currency},
+		StartDate:         boStartDate,
+		EndDate:           boEndDate,
+		OrderNumber:       boOrderNumber,
+		ClientName:        boClientName,
+		PrimaryBuyerName:  boBuyerName,
+		PrimaryBuyerEmail: boBuyerEmail,
+		BillingEmail:      boBillingEmail,
+		SupplySources:     []string{"APPSTORE_SEARCH_RESULTS"},
+	}
+	created, err := services.NewBudgetOrderService(client).Create(orgID, order)
+	if err != nil {
+		return fmt.Errorf("creating budget order: %w", err)
+	}
+
+	output.Print(getFormat(), created, budgetOrderColumns)
+	return nil
+}
+
+func runBudgetOrdersUpdate(cmd *cobra.Command, args []string) error {
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid budget order ID: %s", args[0])
+	}
+	if err := checkBudgetOrderDates(); err != nil {
+		return err
+	}
+
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	update := &models.BudgetOrderUpdate{}
+	hasUpdate := false
+	for flag, field := range map[string]*string{
+		"name":          &update.Name,
+		"start-date":    &update.StartDate,
+		"end-date":      &update.EndDate,
+		"order-number":  &update.OrderNumber,
+		"client-name":   &update.ClientName,
+		"buyer-name":    &update.PrimaryBuyerName,
+		"buyer-email":   &update.PrimaryBuyerEmail,
+		"billing-email": &update.BillingEmail,
+	} {
+		if cmd.Flags().Changed(flag) {
+			*field, _ = cmd.Flags().GetString(flag)
+			hasUpdate = true
+		}
+	}
+	if cmd.Flags().Changed("budget") {
+		currency, err := resolveOrgCurrency(client)
+		if err != nil {
+			return err
+		}
+		update.Budget = &models.Money{Amount: boBudget, Currency: currency}
+		hasUpdate = true
+	}
+
+	if !hasUpdate {
+		return fmt.Errorf("no update flags provided")
+	}
+
+	orgID, err := requestOrgID()
+	if err != nil {
+		return err
+	}
+
+	svc := services.NewBudgetOrderService(client)
+	if dryRun {
+		before, err := svc.Get(id)
+		if err != nil {
+			return fmt.Errorf("getting budget order: %w", err)
+		}
+		previewUpdate(fmt.Sprintf("Budget order %d", id), before, update)
+	}
+
+	updated, err := svc.Update(orgID, id, update)
+	if err != nil {
+		return fmt.Errorf("updating budget order: %w", err)
+	}
+
+	output.Print(getFormat(), updated, budgetOrderColumns)
+	return nil
+}
+
+func checkBudgetOrderDates() error {
+	for flag, value := range map[string]string{"start-date": boStartDate, "end-date": boEndDate} {
+		if value == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", value); err != nil {
+			return fmt.Errorf("invalid --%s %q (expected YYYY-MM-DD)", flag, value)
+		}
+	}
+	return nil
+}
+
+// budgetOrderBalanceOf adds up what the campaigns drawing from a budget
+// order have spent since it started.
+func budgetOrderBalanceOf(client *api.Client, order models.BudgetOrder, campaigns []models.Campaign) (*budgetOrderBalance, error) {
+	balance := &budgetOrderBalance{BudgetOrder: order}
+	budget := order.Budget.Float()
+	balance.Remaining = budget
+
+	var ids []string
+	for _, c := range campaigns {
+		for _, boID := range c.BudgetOrders {
+			if boID == order.ID {
+				ids = append(ids, strconv.FormatInt(c.ID, 10))
+				break
+			}
+		}
+	}
+	if len(ids) == 0 || len(order.StartDate) < 10 {
+		return balance, nil
+	}
+
+	start := order.StartDate[:10]
+	end := time.Now().Format("2006-01-02")
+	if len(order.EndDate) >= 10 && order.EndDate[:10] < end {
+		end = order.EndDate[:10]
+	}
+	if start > end {
+		return balance, nil
+	}
+
+	resp, err := services.NewReportingService(client).GetCampaignReport(pacingReportRequest(ids, start, end, ""))
+	if err != nil {
+		return nil, fmt.Errorf("getting spend for budget order %d: %w", order.ID, err)
+	}
+	for _, row := range resp.Row {
+		if row.Total != nil {
+			balance.Spent += row.Total.LocalSpend.Float()
+		}
+	}
+	balance.Spent = round2(balance.Spent)
+	balance.Remaining = round2(budget - balance.Spent)
+	return balance, nil
+}
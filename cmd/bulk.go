@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/trebuhs/asa-cli/internal/api"
+	"github.com/trebuhs/asa-cli/internal/output"
+)
+
+// record is one data row of a CSV/TSV file, keyed by normalized header name.
+type record struct {
+	Line   int
+	Fields map[string]string
+}
+
+func (r record) get(name string) string {
+	return strings.TrimSpace(r.Fields[name])
+}
+
+// readRecords reads a CSV file (or TSV for .tsv/.tab files) with a header row.
+// Header names are lower-cased with spaces and dashes turned into underscores.
+func readRecords(path string) ([]record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening %s: %w", path, err)
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".tsv", ".tab":
+		reader.Comma = '\t'
+	}
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading header of %s: %w", path, err)
+	}
+	for i, h := range header {
+		h = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))
+		header[i] = strings.NewReplacer(" ", "_", "-", "_").Replace(h)
+	}
+
+	var records []record
+	line := 1
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line++
+		if err != nil {
+			return nil, fmt.Errorf("reading %s line %d: %w", path, line, err)
+		}
+		if len(row) == 1 && strings.TrimSpace(row[0]) == "" {
+			continue
+		}
+		fields := map[string]string{}
+		for i, value := range row {
+			if i < len(header) {
+				fields[header[i]] = value
+			}
+		}
+		records = append(records, record{Line: line, Fields: fields})
+	}
+	return records, nil
+}
+
+// parseOptionalID parses an ID column, falling back to def when it is empty.
+func parseOptionalID(value string, def int64) (int64, error) {
+	if value == "" {
+		return def, nil
+	}
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid ID %q", value)
+	}
+	return id, nil
+}
+
+// bulkResult is the outcome of one input row of a bulk command.
+type bulkResult struct {
+	Row       int
+	ID        int64
+	Text      string
+	MatchType string
+	AdGroupID int64
+	Result    string
+	Reason    string
+}
+
+var bulkResultColumns = []output.Column{
+	{Header: "ROW", Field: "Row", Width: 6},
+	{Header: "ID", Field: "ID", Width: 12},
+	{Header: "TEXT", Field: "Text", Width: 30},
+	{Header: "MATCH TYPE", Field: "MatchType", Width: 12},
+	{Header: "AD GROUP", Field: "AdGroupID", Width: 12},
+	{Header: "RESULT", Field: "Result", Width: 10},
+	{Header: "REASON", Field: "Reason", Width: 40},
+}
+
+// resultDryRun marks rows that were printed but not sent because of --dry-run.
+const resultDryRun = "dry-run"
+
+// resultUnchanged marks rows that already match live state and were not sent.
+const resultUnchanged = "unchanged"
+
+// printBulkResults prints per-row results and returns an error if any row failed.
+func printBulkResults(results []bulkResult, ok string) error {
+	output.Print(getFormat(), results, bulkResultColumns)
+
+	failed := 0
+	for _, r := range results {
+		if r.Result != ok && r.Result != resultDryRun && r.Result != resultUnchanged {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d row(s) failed", failed, len(results))
+	}
+	return nil
+}
+
+var fieldIndexPattern = regexp.MustCompile(`\[(\d+)\]`)
+
+// apiErrorReason describes an API error and, when the error's Field points at
+// an element of a bulk request (e.g. "keywords[3].text"), returns its index.
+func apiErrorReason(err error) (reason string, index int) {
+	var apiErr *api.Error
+	if !errors.As(err, &apiErr) || len(apiErr.Errors) == 0 {
+		return err.Error(), -1
+	}
+	e := apiErr.Errors[0]
+	reason = e.Message
+	if e.Field != "" {
+		reason = fmt.Sprintf("%s: %s", e.Field, e.Message)
+	}
+	if m := fieldIndexPattern.FindStringSubmatch(e.Field); m != nil {
+		if i, convErr := strconv.Atoi(m[1]); convErr == nil {
+			return reason, i
+		}
+	}
+	return reason, -1
+}
+
+// bulkRow is an input row that carries its own result.
+type bulkRow interface {
+	outcome() *bulkResult
+}
+
+// sendBulk sends one bulk request. When the API rejects a single element of
+// the batch, that row is marked with failed and the rest are retried; any
+// other error marks the whole batch. On success, send records the results.
+func sendBulk[T bulkRow](batch []T, failed string, send func([]T) error) {
+	for len(batch) > 0 {
+		err := send(batch)
+		if err == nil {
+			if dryRun {
+				for _, row := range batch {
+					row.outcome().Result = resultDryRun
+				}
+			}
+			return
+		}
+
+		reason, index := apiErrorReason(err)
+		if index < 0 || index >= len(batch) {
+			for _, row := range batch {
+				res := row.outcome()
+				res.Result, res.Reason = failed, reason
+			}
+			return
+		}
+		res := batch[index].outcome()
+		res.Result, res.Reason = failed, reason
+		batch = append(batch[:index:index], batch[index+1:]...)
+	}
+}
@@ -1,14 +1,20 @@
 package cmd
 
 import (
+	"bytes"
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
+	"text/template"
 
 	"github.com/spf13/cobra"
+	"github.com/trebuhs/asa-cli/internal/api"
 	"github.com/trebuhs/asa-cli/internal/models"
 	"github.com/trebuhs/asa-cli/internal/output"
 	"github.com/trebuhs/asa-cli/internal/services"
+	"github.com/trebuhs/asa-cli/internal/state"
+	"go.yaml.in/yaml/v3"
 )
 
 var campaignsCmd = &cobra.Command{
@@ -55,6 +61,18 @@ var campaignsDeleteCmd = &cobra.Command{
 	RunE:  runCampaignsDelete,
 }
 
+var campaignsCloneCmd = &cobra.Command{
+	Use:   "clone <id>",
+	Short: "Clone a campaign into new storefronts",
+	Long: `Copy a campaign with all its ad groups (targeting and default bids), keywords
+and negative keywords into one new campaign per target country or region.
+
+Bids and CPA goals are converted with --fx-table (a YAML map of country to
+rate) or --bid-multiplier; countries missing from both keep the source bids.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCampaignsClone,
+}
+
 var (
 	campLimit     int
 	campOffset    int
@@ -67,6 +85,11 @@ var (
 	campCountries string
 	campAppID     int64
 	campStatus    string
+
+	cloneNameTemplate string
+	cloneFXTable      string
+	cloneMultipliers  map[string]string
+	clonePaused       bool
 )
 
 func init() {
@@ -100,7 +123,15 @@ func init() {
 	campaignsUpdateCmd.Flags().StringVar(&campDaily, "daily-budget", "", "Daily budget")
 	campaignsUpdateCmd.Flags().StringVar(&campStatus, "status", "", "Campaign status (ENABLED/PAUSED)")
 
-	campaignsCmd.AddCommand(campaignsListCmd, campaignsGetCmd, campaignsFindCmd, campaignsCreateCmd, campaignsUpdateCmd, campaignsDeleteCmd)
+	// clone
+	campaignsCloneCmd.Flags().StringVar(&campCountries, "countries", "", "Comma-separated target country codes (required)")
+	campaignsCloneCmd.Flags().StringVar(&cloneNameTemplate, "name-template", "{{.Name}} - {{.Country}}", "Go template for new campaign names ({{.Name}}, {{.Country}})")
+	campaignsCloneCmd.Flags().StringVar(&cloneFXTable, "fx-table", "", "YAML file mapping country code to the conversion rate for budgets and bids")
+	campaignsCloneCmd.Flags().StringToStringVar(&cloneMultipliers, "bid-multiplier", nil, "Per-country bid multiplier (e.g. GB=0.8,DE=1.1)")
+	campaignsCloneCmd.Flags().BoolVar(&clonePaused, "paused", false, "Create cloned campaigns as PAUSED")
+	campaignsCloneCmd.MarkFlagRequired("countries")
+
+	campaignsCmd.AddCommand(campaignsListCmd, campaignsGetCmd, campaignsFindCmd, campaignsCreateCmd, campaignsUpdateCmd, campaignsDeleteCmd, campaignsCloneCmd)
 	rootCmd.AddCommand(campaignsCmd)
 }
 
@@ -190,10 +221,6 @@ func runCampaignsCreate(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if err := checkBudgetLimit(campDaily); err != nil {
-		return err
-	}
-
 	campaign := &models.Campaign{
 		Name:               campName,
 		AdamID:             campAppID,
@@ -244,9 +271,6 @@ func runCampaignsUpdate(cmd *cobra.Command, args []string) error {
 			hasUpdate = true
 		}
 		if cmd.Flags().Changed("daily-budget") {
-			if err := checkBudgetLimit(campDaily); err != nil {
-				return err
-			}
 			update.DailyBudgetAmount = &models.Money{Amount: campDaily, Currency: currency}
 			hasUpdate = true
 		}
@@ -261,6 +285,14 @@ func runCampaignsUpdate(cmd *cobra.Command, args []string) error {
 	}
 
 	svc := services.NewCampaignService(client)
+	if dryRun {
+		before, err := svc.Get(id)
+		if err != nil {
+			return fmt.Errorf("getting campaign: %w", err)
+		}
+		previewUpdate(fmt.Sprintf("Campaign %d", id), before, update)
+	}
+
 	updated, err := svc.Update(id, update)
 	if err != nil {
 		return fmt.Errorf("updating campaign: %w", err)
@@ -282,6 +314,22 @@ func runCampaignsDelete(cmd *cobra.Command, args []string) error {
 	}
 
 	svc := services.NewCampaignService(client)
+	campaign, err := svc.Get(id)
+	if err != nil {
+		return fmt.Errorf("getting campaign: %w", err)
+	}
+
+	adgroups, keywords, err := countCampaignChildren(client, id)
+	if err != nil {
+		return err
+	}
+	if err := confirmDestructive("delete a campaign", []string{
+		fmt.Sprintf("Campaign %d %q (status: %s)", campaign.ID, campaign.Name, campaign.Status),
+		fmt.Sprintf("Also deletes %d ad group(s) and %d keyword(s)", adgroups, keywords),
+	}); err != nil {
+		return err
+	}
+
 	if err := svc.Delete(id); err != nil {
 		return fmt.Errorf("deleting campaign: %w", err)
 	}
@@ -289,3 +337,159 @@ func runCampaignsDelete(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Campaign %d deleted.\n", id)
 	return nil
 }
+
+// countCampaignChildren counts the live ad groups and keywords under a campaign.
+func countCampaignChildren(client *api.Client, campaignID int64) (adgroups, keywords int, err error) {
+	ags, err := services.NewAdGroupService(client).FindAll(campaignID, models.NewSelector(1000, 0))
+	if err != nil {
+		return 0, 0, fmt.Errorf("fetching ad groups: %w", err)
+	}
+	kwSvc := services.NewKeywordService(client)
+	for _, ag := range ags {
+		if ag.Deleted {
+			continue
+		}
+		adgroups++
+		kws, err := kwSvc.FindAll(campaignID, ag.ID, models.NewSelector(1000, 0))
+		if err != nil {
+			return 0, 0, fmt.Errorf("fetching keywords: %w", err)
+		}
+		for _, kw := range kws {
+			if !kw.Deleted {
+				keywords++
+			}
+		}
+	}
+	return adgroups, keywords, nil
+}
+
+func runCampaignsClone(cmd *cobra.Command, args []string) error {
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid campaign ID: %s", args[0])
+	}
+
+	tmpl, err := template.New("name").Parse(cloneNameTemplate)
+	if err != nil {
+		return fmt.Errorf("invalid --name-template: %w", err)
+	}
+
+	countries, err := cloneCountries()
+	if err != nil {
+		return err
+	}
+	fx, multipliers, err := loadBidFactors()
+	if err != nil {
+		return err
+	}
+
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	source, err := state.FetchCampaign(client, id)
+	if err != nil {
+		return err
+	}
+
+	var clones []*state.Campaign
+	for _, country := range countries {
+		var name bytes.Buffer
+		data := map[string]string{"Name": source.Campaign.Name, "Country": country}
+		if err := tmpl.Execute(&name, data); err != nil {
+			return fmt.Errorf("rendering campaign name for %s: %w", country, err)
+		}
+
+		// The FX rate converts budgets and bids; a multiplier overrides it
+		// for bids only.
+		budgetFactor, bidFactor := 1.0, 1.0
+		if f, ok := fx[country]; ok {
+			budgetFactor, bidFactor = f, f
+		}
+		if f, ok := multipliers[country]; ok {
+			bidFactor = f
+		}
+		clones = append(clones, source.ForCountry(name.String(), country, bidFactor, budgetFactor))
+	}
+
+	for _, clone := range clones {
+		if err := checkAccountLimits(&state.Account{Campaigns: []state.Campaign{*clone}}); err != nil {
+			return err
+		}
+	}
+	if err := checkChangeCount(client, len(clones)*source.EntityCount()); err != nil {
+		return err
+	}
+
+	currency, err := resolveOrgCurrency(client)
+	if err != nil {
+		return err
+	}
+
+	importer := state.NewImporter(client, currency)
+	// Clones stay in the source org, so its invoice details still apply.
+	importer.CopyInvoiceDetails = true
+	if clonePaused {
+		importer.Status = "PAUSED"
+	}
+	for _, clone := range clones {
+		if _, err := importer.ImportCampaign(clone); err != nil {
+			return fmt.Errorf("cloning campaign: %w", err)
+		}
+	}
+
+	output.Print(getFormat(), importer.Mappings, idMappingColumns)
+	return nil
+}
+
+// cloneCountries parses --countries, rejecting empty entries.
+func cloneCountries() ([]string, error) {
+	var countries []string
+	for _, country := range strings.Split(campCountries, ",") {
+		country = strings.ToUpper(strings.TrimSpace(country))
+		if country == "" {
+			return nil, fmt.Errorf("invalid --countries %q: empty country code", campCountries)
+		}
+		countries = append(countries, country)
+	}
+	return countries, nil
+}
+
+// loadBidFactors reads the per-country rates of --fx-table and
+// --bid-multiplier.
+func loadBidFactors() (fx, multipliers map[string]float64, err error) {
+	fx = map[string]float64{}
+	multipliers = map[string]float64{}
+
+	if cloneFXTable != "" {
+		data, err := os.ReadFile(cloneFXTable)
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading FX table: %w", err)
+		}
+		var table map[string]float64
+		if err := yaml.Unmarshal(data, &table); err != nil {
+			return nil, nil, fmt.Errorf("parsing FX table %s: %w", cloneFXTable, err)
+		}
+		for country, rate := range table {
+			fx[strings.ToUpper(country)] = rate
+		}
+	}
+
+	for country, value := range cloneMultipliers {
+		rate, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid --bid-multiplier for %s: %q", country, value)
+		}
+		multipliers[strings.ToUpper(country)] = rate
+	}
+
+	for _, factors := range []map[string]float64{fx, multipliers} {
+		for country, rate := range factors {
+			if rate <= 0 {
+				return nil, nil, fmt.Errorf("conversion rate for %s must be positive, got %v", country, rate)
+			}
+		}
+	}
+	return fx, multipliers, nil
+}
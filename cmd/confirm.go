@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mattn/go-isatty"
+	"github.com/trebuhs/asa-cli/internal/models"
+)
+
+// confirmDestructive prints what is about to be deleted and asks for
+// confirmation. --yes skips the prompt; when stdin is not a terminal the
+// command is refused unless --yes is given. Dry runs never prompt.
+func confirmDestructive(action string, details []string) error {
+	if yesFlag || dryRun {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "About to %s:\n", action)
+	for _, d := range details {
+		fmt.Fprintf(os.Stderr, "  %s\n", d)
+	}
+
+	if !isatty.IsTerminal(os.Stdin.Fd()) && !isatty.IsCygwinTerminal(os.Stdin.Fd()) {
+		return fmt.Errorf("refusing to %s without confirmation: stdin is not a terminal (pass --yes to proceed)", action)
+	}
+
+	fmt.Fprint(os.Stderr, "Proceed? [y/N]: ")
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	}
+	return fmt.Errorf("aborted")
+}
+
+// idCondition selects entities whose ID is one of ids.
+func idCondition(ids []int64) models.Condition {
+	values := make([]string, len(ids))
+	for i, id := range ids {
+		values[i] = strconv.FormatInt(id, 10)
+	}
+	return models.Condition{Field: "id", Operator: "IN", Values: values}
+}
@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/trebuhs/asa-cli/internal/api"
+	"github.com/trebuhs/asa-cli/internal/models"
+	"github.com/trebuhs/asa-cli/internal/output"
+	"github.com/trebuhs/asa-cli/internal/services"
+)
+
+var creativesCmd = &cobra.Command{
+	Use:   "creatives",
+	Short: "Manage creatives (custom product pages used by ads)",
+}
+
+var creativesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List creatives",
+	RunE:  runCreativesList,
+}
+
+var creativesGetCmd = &cobra.Command{
+	Use:   "get <id>",
+	Short: "Get a creative by ID",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runCreativesGet,
+}
+
+var creativesFindCmd = &cobra.Command{
+	Use:   "find",
+	Short: "Find creatives with filters",
+	RunE:  runCreativesFind,
+}
+
+var creativesCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a creative from a custom product page",
+	RunE:  runCreativesCreate,
+}
+
+var (
+	creativeLimit   int
+	creativeOffset  int
+	creativeFilters []string
+	creativeSorts   []string
+	creativeAll     bool
+	creativeAdamID  int64
+	creativeName    string
+	creativePageID  string
+)
+
+func init() {
+	// list
+	creativesListCmd.Flags().IntVar(&creativeLimit, "limit", 20, "Number of results")
+	creativesListCmd.Flags().IntVar(&creativeOffset, "offset", 0, "Results offset")
+
+	// find
+	creativesFindCmd.Flags().StringSliceVar(&creativeFilters, "filter", nil, `Filter conditions`)
+	creativesFindCmd.Flags().StringSliceVar(&creativeSorts, "sort", nil, `Sort order`)
+	creativesFindCmd.Flags().IntVar(&creativeLimit, "limit", 20, "Number of results")
+	creativesFindCmd.Flags().IntVar(&creativeOffset, "offset", 0, "Results offset")
+	creativesFindCmd.Flags().BoolVar(&creativeAll, "all", false, "Fetch all pages")
+
+	// create
+	creativesCreateCmd.Flags().Int64Var(&creativeAdamID, "adam-id", 0, "App Adam ID (required)")
+	creativesCreateCmd.Flags().StringVar(&creativePageID, "product-page-id", "", "Custom product page ID (required)")
+	creativesCreateCmd.Flags().StringVar(&creativeName, "name", "", "Creative name (default: the product page name)")
+	creativesCreateCmd.MarkFlagRequired("adam-id")
+	creativesCreateCmd.MarkFlagRequired("product-page-id")
+
+	creativesCmd.AddCommand(creativesListCmd, creativesGetCmd, creativesFindCmd, creativesCreateCmd)
+	rootCmd.AddCommand(creativesCmd)
+}
+
+var creativeColumns = []output.Column{
+	{Header: "ID", Field: "ID", Width: 12},
+	{Header: "NAME", Field: "Name", Width: 30},
+	{Header: "ADAM ID", Field: "AdamID", Width: 12},
+	{Header: "TYPE", Field: "Type", Width: 22},
+	{Header: "PRODUCT PAGE", Field: "ProductPageID", Width: 38},
+	{Header: "STATE", Field: "State", Width: 10},
+}
+
+func runCreativesList(cmd *cobra.Command, args []string) error {
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	svc := services.NewCreativeService(client)
+	creatives, _, err := svc.List(creativeLimit, creativeOffset)
+	if err != nil {
+		return fmt.Errorf("listing creatives: %w", err)
+	}
+
+	output.Print(getFormat(), creatives, creativeColumns)
+	return nil
+}
+
+func runCreativesGet(cmd *cobra.Command, args []string) error {
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid creative ID: %s", args[0])
+	}
+
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	svc := services.NewCreativeService(client)
+	creative, err := svc.Get(id)
+	if err != nil {
+		return fmt.Errorf("getting creative: %w", err)
+	}
+
+	output.Print(getFormat(), creative, creativeColumns)
+	return nil
+}
+
+func runCreativesFind(cmd *cobra.Command, args []string) error {
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	selector := models.NewSelector(creativeLimit, creativeOffset)
+	selector.Conditions = parseFilters(creativeFilters)
+	selector.OrderBy = parseSorts(creativeSorts)
+
+	svc := services.NewCreativeService(client)
+
+	if creativeAll {
+		creatives, err := svc.FindAll(selector)
+		if err != nil {
+			return fmt.Errorf("finding creatives: %w", err)
+		}
+		output.Print(getFormat(), creatives, creativeColumns)
+	} else {
+		creatives, _, err := svc.Find(selector)
+		if err != nil {
+			return fmt.Errorf("finding creatives: %w", err)
+		}
+		output.Print(getFormat(), creatives, creativeColumns)
+	}
+	return nil
+}
+
+func runCreativesCreate(cmd *cobra.Command, args []string) error {
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	creative, err := productPageCreative(client, creativeAdamID, creativePageID, creativeName, false)
+	if err != nil {
+		return err
+	}
+
+	output.Print(getFormat(), creative, creativeColumns)
+	return nil
+}
+
+// productPageCreative creates a CUSTOM_PRODUCT_PAGE creative for a product
+// page, named after the page unless name is set. With reuse, an existing
+// creative for the same page is returned instead of creating another.
+func productPageCreative(client *api.Client, adamID int64, pageID, name string, reuse bool) (*models.Creative, error) {
+	svc := services.NewCreativeService(client)
+
+	if reuse {
+		selector := models.NewSelector(1000, 0)
+		selector.Conditions = []models.Condition{
+			{Field: "adamId", Operator: "EQUALS", Values: []string{strconv.FormatInt(adamID, 10)}},
+			{Field: "productPageId", Operator: "EQUALS", Values: []string{pageID}},
+		}
+		existing, err := svc.FindAll(selector)
+		if err != nil {
+			return nil, fmt.Errorf("finding creatives: %w", err)
+		}
+		for i := range existing {
+			if existing[i].ProductPageID == pageID {
+				return &existing[i], nil
+			}
+		}
+	}
+
+	page, err := services.NewAppService(client).GetProductPage(adamID, pageID)
+	if err != nil {
+		return nil, fmt.Errorf("getting product page %s: %w", pageID, err)
+	}
+	if name == "" {
+		name = page.Name
+	}
+
+	created, err := svc.Create(&models.Creative{
+		AdamID:        adamID,
+		Name:          name,
+		Type:          "CUSTOM_PRODUCT_PAGE",
+		ProductPageID: page.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating creative: %w", err)
+	}
+	return created, nil
+}
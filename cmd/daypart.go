@@ -0,0 +1,267 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/trebuhs/asa-cli/internal/api"
+	"github.com/trebuhs/asa-cli/internal/daypart"
+	"github.com/trebuhs/asa-cli/internal/models"
+	"github.com/trebuhs/asa-cli/internal/output"
+	"github.com/trebuhs/asa-cli/internal/services"
+)
+
+var adgroupsDaypartCmd = &cobra.Command{
+	Use:   "daypart",
+	Short: "Manage ad group dayparting (hours of the week ads run)",
+}
+
+var adgroupsDaypartShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show an ad group's daypart schedule as a weekly grid",
+	RunE:  runDaypartShow,
+}
+
+var adgroupsDaypartSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Set an ad group's daypart schedule",
+	Long: `Set the hours of the week an ad group runs, in the user's time zone.
+
+Entries are separated by ";", each a set of days and one or more hour ranges:
+  --schedule "mon-fri 08:00-22:00; sat,sun 10:00-23:00"
+  --schedule "daily 06:00-24:00"
+  --schedule all        (remove dayparting)`,
+	RunE: runDaypartSet,
+}
+
+var adgroupsDaypartSuggestCmd = &cobra.Command{
+	Use:   "suggest",
+	Short: "Suggest a schedule from an hourly report",
+	Long: `Suggest a schedule from an hourly ad group report.
+
+Daypart schedules are in the user's (searcher's) time zone. The report is
+requested in UTC and converted to --time-zone, which defaults to the zone of
+the campaign's country when it targets a single-zone storefront.`,
+	RunE: runDaypartSuggest,
+}
+
+var (
+	dpAdGroupID   int64
+	dpSchedule    string
+	dpStartDate   string
+	dpEndDate     string
+	dpMaxCPIRatio float64
+	dpTimeZone    string
+)
+
+func init() {
+	for _, cmd := range []*cobra.Command{adgroupsDaypartShowCmd, adgroupsDaypartSetCmd, adgroupsDaypartSuggestCmd} {
+		cmd.Flags().Int64Var(&agCampaignID, "campaign-id", 0, "Campaign ID (required)")
+		cmd.Flags().Int64Var(&dpAdGroupID, "id", 0, "Ad group ID (required)")
+		cmd.MarkFlagRequired("campaign-id")
+		cmd.MarkFlagRequired("id")
+	}
+
+	adgroupsDaypartSetCmd.Flags().StringVar(&dpSchedule, "schedule", "", `Weekly schedule, e.g. "mon-fri 08:00-22:00; sat,sun 10:00-23:00" (required)`)
+	adgroupsDaypartSetCmd.MarkFlagRequired("schedule")
+
+	adgroupsDaypartSuggestCmd.Flags().StringVar(&dpStartDate, "start-date", "", "Report start date YYYY-MM-DD (default: 28 days ago)")
+	adgroupsDaypartSuggestCmd.Flags().StringVar(&dpEndDate, "end-date", "", "Report end date YYYY-MM-DD (default: yesterday)")
+	adgroupsDaypartSuggestCmd.Flags().Float64Var(&dpMaxCPIRatio, "max-cpi-ratio", 1.5, "Drop hours whose CPI exceeds this multiple of the average")
+	adgroupsDaypartSuggestCmd.Flags().StringVar(&dpTimeZone, "time-zone", "", `Users' time zone, e.g. "America/New_York" (default: the campaign country's zone)`)
+
+	adgroupsDaypartCmd.AddCommand(adgroupsDaypartShowCmd, adgroupsDaypartSetCmd, adgroupsDaypartSuggestCmd)
+	adgroupsCmd.AddCommand(adgroupsDaypartCmd)
+}
+
+// daypartView is the JSON form of a schedule.
+type daypartView struct {
+	AdGroupID int64  `json:"adGroupId"`
+	Schedule  string `json:"schedule"`
+	Hours     []int  `json:"hours"`
+}
+
+func printDaypart(adGroupID int64, hours []int) {
+	if getFormat() == output.FormatJSON {
+		output.Print(getFormat(), daypartView{AdGroupID: adGroupID, Schedule: daypart.Format(hours), Hours: hours}, nil)
+		return
+	}
+	fmt.Printf("Schedule: %s\n\n", daypart.Format(hours))
+	for _, line := range daypart.Grid(hours) {
+		fmt.Println(line)
+	}
+}
+
+// dayPartHours returns the scheduled hours of targeting, or nil for all hours.
+func dayPartHours(td *models.TargetingDimensions) []int {
+	if td == nil || td.DayPart == nil || td.DayPart.UserTime == nil {
+		return nil
+	}
+	return td.DayPart.UserTime.Included
+}
+
+func runDaypartShow(cmd *cobra.Command, args []string) error {
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	adgroup, err := services.NewAdGroupService(client).Get(agCampaignID, dpAdGroupID)
+	if err != nil {
+		return fmt.Errorf("getting ad group: %w", err)
+	}
+
+	printDaypart(dpAdGroupID, dayPartHours(adgroup.TargetingDimensions))
+	return nil
+}
+
+func runDaypartSet(cmd *cobra.Command, args []string) error {
+	var hours []int
+	if !strings.EqualFold(strings.TrimSpace(dpSchedule), "all") {
+		var err error
+		hours, err = daypart.Parse(dpSchedule)
+		if err != nil {
+			return err
+		}
+	}
+
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	svc := services.NewAdGroupService(client)
+	before, err := svc.Get(agCampaignID, dpAdGroupID)
+	if err != nil {
+		return fmt.Errorf("getting ad group: %w", err)
+	}
+
+	td := currentTargeting(before)
+	td.DayPart = nil
+	if len(hours) > 0 && len(hours) < daypart.HoursPerWeek {
+		td.DayPart = &models.DayPartCriteria{UserTime: &models.DayPartDetail{Included: hours}}
+	}
+
+	if dryRun {
+		fmt.Printf("Ad group %d:\n  daypart: %s -> %s\n",
+			dpAdGroupID, daypart.Format(dayPartHours(before.TargetingDimensions)), daypart.Format(hours))
+	}
+
+	updated, err := svc.Update(agCampaignID, dpAdGroupID, &models.AdGroupUpdate{TargetingDimensions: &td})
+	if err != nil {
+		return fmt.Errorf("updating ad group: %w", err)
+	}
+
+	printDaypart(dpAdGroupID, dayPartHours(updated.TargetingDimensions))
+	return nil
+}
+
+func runDaypartSuggest(cmd *cobra.Command, args []string) error {
+	end := time.Now().AddDate(0, 0, -1)
+	if dpEndDate != "" {
+		var err error
+		if end, err = time.Parse("2006-01-02", dpEndDate); err != nil {
+			return fmt.Errorf("invalid --end-date %q (expected YYYY-MM-DD)", dpEndDate)
+		}
+	}
+	start := end.AddDate(0, 0, -27)
+	if dpStartDate != "" {
+		var err error
+		if start, err = time.Parse("2006-01-02", dpStartDate); err != nil {
+			return fmt.Errorf("invalid --start-date %q (expected YYYY-MM-DD)", dpStartDate)
+		}
+	}
+
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	req := &models.ReportRequest{
+		StartTime:   start.Format("2006-01-02"),
+		EndTime:     end.Format("2006-01-02"),
+		Granularity: "HOURLY",
+		TimeZone:    "UTC",
+		Selector: &models.Selector{
+			Conditions: []models.Condition{
+				{Field: "adGroupId", Operator: "EQUALS", Values: []string{strconv.FormatInt(dpAdGroupID, 10)}},
+			},
+			Pagination: models.SelectorPagination{Offset: 0, Limit: 1000},
+		},
+	}
+	loc, err := daypartLocation(client, agCampaignID)
+	if err != nil {
+		return err
+	}
+
+	resp, err := services.NewReportingService(client).GetAdGroupReport(agCampaignID, req)
+	if err != nil {
+		return fmt.Errorf("getting hourly report: %w", err)
+	}
+
+	var stats [daypart.HoursPerWeek]daypart.Stats
+	for _, row := range resp.Row {
+		for _, g := range row.Granularity {
+			t, ok := parseReportHour(g.Date)
+			if !ok || g.Metrics == nil {
+				continue
+			}
+			t = t.In(loc)
+			h := int(t.Weekday())*24 + t.Hour()
+			stats[h].Spend += g.Metrics.LocalSpend.Float()
+			stats[h].Installs += g.Metrics.TotalInstalls
+		}
+	}
+
+	hours := daypart.Suggest(stats, dpMaxCPIRatio)
+	if hours == nil {
+		return fmt.Errorf("no installs between %s and %s to base a schedule on", req.StartTime, req.EndTime)
+	}
+
+	printDaypart(dpAdGroupID, hours)
+	if getFormat() != output.FormatJSON {
+		fmt.Printf("\nHours are in %s, the users' time zone.\n", loc)
+		fmt.Printf("\nApply with:\n  asa-cli adgroups daypart set --campaign-id %d --id %d --schedule %q\n",
+			agCampaignID, dpAdGroupID, daypart.Format(hours))
+	}
+	return nil
+}
+
+// daypartLocation returns the users' time zone: --time-zone, or the zone of
+// the campaign's only country.
+func daypartLocation(client *api.Client, campaignID int64) (*time.Location, error) {
+	name := dpTimeZone
+	if name == "" {
+		campaign, err := services.NewCampaignService(client).Get(campaignID)
+		if err != nil {
+			return nil, fmt.Errorf("getting campaign: %w", err)
+		}
+		countries := campaign.CountriesOrRegions
+		tz, ok := "", false
+		if len(countries) == 1 {
+			tz, ok = daypart.CountryTimeZone(countries[0])
+		}
+		if !ok {
+			return nil, fmt.Errorf("cannot tell the users' time zone for countries %s; pass --time-zone", strings.Join(countries, ","))
+		}
+		name = tz
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --time-zone %q: %w", name, err)
+	}
+	return loc, nil
+}
+
+// parseReportHour parses the date of an hourly report row, which is in UTC.
+func parseReportHour(s string) (time.Time, bool) {
+	for _, layout := range []string{"2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02 15"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
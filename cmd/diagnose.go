@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/trebuhs/asa-cli/internal/models"
+	"github.com/trebuhs/asa-cli/internal/output"
+	"github.com/trebuhs/asa-cli/internal/services"
+	"github.com/trebuhs/asa-cli/internal/serving"
+)
+
+var diagnoseCmd = &cobra.Command{
+	Use:   "diagnose",
+	Short: "Explain why enabled campaigns and ad groups are not running",
+	Long: `Walk every enabled campaign and ad group and list everything that is not
+running: campaigns and ad groups whose serving status is NOT_RUNNING, and
+countries a campaign cannot serve in. Each reason comes with a suggested fix.`,
+	RunE: runDiagnose,
+}
+
+var diagnoseCampaigns []string
+
+func init() {
+	diagnoseCmd.Flags().StringSliceVar(&diagnoseCampaigns, "campaigns", nil, `Campaign filter, same syntax as --filter (e.g. "name~Brand") (default: all campaigns)`)
+
+	rootCmd.AddCommand(diagnoseCmd)
+}
+
+// diagnosis is one reason a campaign, ad group or country is not running.
+type diagnosis struct {
+	CampaignID  int64  `json:"campaignId"`
+	Campaign    string `json:"campaign"`
+	AdGroupID   int64  `json:"adGroupId,omitempty"`
+	AdGroup     string `json:"adGroup,omitempty"`
+	Country     string `json:"country,omitempty"`
+	Reason      string `json:"reason"`
+	Description string `json:"description"`
+	Fix         string `json:"fix,omitempty"`
+}
+
+var diagnosisColumns = []output.Column{
+	{Header: "CAMPAIGN", Field: "Campaign", Width: 25},
+	{Header: "AD GROUP", Field: "AdGroup", Width: 20},
+	{Header: "COUNTRY", Field: "Country", Width: 8},
+	{Header: "REASON", Field: "Description", Width: 35},
+	{Header: "FIX", Field: "Fix", Width: 50},
+}
+
+func runDiagnose(cmd *cobra.Command, args []string) error {
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	selector := models.NewSelector(1000, 0)
+	selector.Conditions = parseFilters(diagnoseCampaigns)
+	campaigns, err := services.NewCampaignService(client).FindAll(selector)
+	if err != nil {
+		return fmt.Errorf("finding campaigns: %w", err)
+	}
+
+	agSvc := services.NewAdGroupService(client)
+	diagnoses := []diagnosis{}
+	for _, c := range campaigns {
+		if c.Deleted || c.Status != "ENABLED" {
+			continue
+		}
+		campaignDown := c.ServingStatus == "NOT_RUNNING"
+		if campaignDown {
+			codes := c.ServingStateReasons
+			if len(codes) == 0 {
+				codes = []string{"NOT_RUNNING"}
+			}
+			for _, code := range codes {
+				diagnoses = append(diagnoses, explain(c, nil, "", code))
+			}
+		}
+		countries, byCountry := serving.CountryReasons(c.CountryOrRegionServingStateReasons)
+		for _, country := range countries {
+			for _, code := range byCountry[country] {
+				diagnoses = append(diagnoses, explain(c, nil, country, code))
+			}
+		}
+
+		adGroups, err := agSvc.FindAll(c.ID, models.NewSelector(1000, 0))
+		if err != nil {
+			return fmt.Errorf("fetching ad groups for campaign %d: %w", c.ID, err)
+		}
+		for i := range adGroups {
+			ag := &adGroups[i]
+			if ag.Deleted || ag.Status != "ENABLED" || ag.ServingStatus != "NOT_RUNNING" {
+				continue
+			}
+			codes := ag.ServingStateReasons
+			if len(codes) == 0 {
+				codes = []string{"NOT_RUNNING"}
+			}
+			for _, code := range codes {
+				// Already explained by the campaign's own reasons.
+				if code == "CAMPAIGN_NOT_RUNNING" && campaignDown {
+					continue
+				}
+				diagnoses = append(diagnoses, explain(c, ag, "", code))
+			}
+		}
+	}
+
+	if len(diagnoses) == 0 && getFormat() == output.FormatTable {
+		fmt.Println("All enabled campaigns and ad groups are running.")
+		return nil
+	}
+	output.Print(getFormat(), diagnoses, diagnosisColumns)
+	return nil
+}
+
+func explain(c models.Campaign, ag *models.AdGroup, country, code string) diagnosis {
+	d := diagnosis{CampaignID: c.ID, Campaign: c.Name, Country: country, Reason: code}
+	var adGroupID int64
+	if ag != nil {
+		adGroupID = ag.ID
+		d.AdGroupID, d.AdGroup = ag.ID, ag.Name
+	}
+	if code == "NOT_RUNNING" {
+		d.Description = "not running; the API gave no reason"
+		return d
+	}
+	d.Description, d.Fix = serving.Explain(code, c.ID, adGroupID)
+	return d
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/trebuhs/asa-cli/internal/output"
+	"github.com/trebuhs/asa-cli/internal/state"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <old.json> [new.json]",
+	Short: "Compare two snapshots, or a snapshot against live state",
+	Long: `Report campaigns, ad groups, keywords and negative keywords that were added,
+removed or changed between two snapshots created with 'asa-cli export'.
+With a single snapshot, compare it against the live account.
+
+Entities are paired by ID. Use --match-by name to compare snapshots taken
+from different orgs.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runDiff,
+}
+
+var diffMatchBy string
+
+func init() {
+	diffCmd.Flags().StringVar(&diffMatchBy, "match-by", "id", "Pair entities by: id or name")
+	rootCmd.AddCommand(diffCmd)
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	by := state.MatchBy(diffMatchBy)
+	if by != state.MatchByID && by != state.MatchByName {
+		return fmt.Errorf("invalid --match-by %q: use id or name", diffMatchBy)
+	}
+
+	old, err := state.ReadSnapshot(args[0])
+	if err != nil {
+		return err
+	}
+
+	var cur *state.Account
+	if len(args) == 2 {
+		snap, err := state.ReadSnapshot(args[1])
+		if err != nil {
+			return err
+		}
+		cur = &snap.Account
+	} else {
+		client, err := newAPIClient()
+		if err != nil {
+			return err
+		}
+		cur, err = state.Fetch(client, nil)
+		if err != nil {
+			return fmt.Errorf("fetching live state: %w", err)
+		}
+	}
+
+	diffs := state.Diff(&old.Account, cur, by)
+	if getFormat() == output.FormatJSON {
+		output.Print(output.FormatJSON, diffs, nil)
+		return nil
+	}
+
+	if len(diffs) == 0 {
+		fmt.Println("No differences.")
+		return nil
+	}
+
+	counts := map[state.ChangeType]int{}
+	for _, d := range diffs {
+		counts[d.Change]++
+		fmt.Printf("%s %s %s\n", changeSymbol(d.Change), d.Kind, d.Path)
+		for _, f := range d.Fields {
+			fmt.Printf("    %s: %q -> %q\n", f.Field, f.Old, f.New)
+		}
+	}
+	fmt.Printf("\n%d added, %d removed, %d changed.\n", counts[state.Added], counts[state.Removed], counts[state.Changed])
+	return nil
+}
+
+func changeSymbol(change state.ChangeType) string {
+	switch change {
+	case state.Added:
+		return color.GreenString("+")
+	case state.Removed:
+		return color.RedString("-")
+	default:
+		return color.YellowString("~")
+	}
+}
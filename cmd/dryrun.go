@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/trebuhs/asa-cli/internal/api"
+	"github.com/trebuhs/asa-cli/internal/output"
+)
+
+// plannedClients are the clients created by the command, whose dry-run
+// requests are printed when it finishes.
+var plannedClients []*api.Client
+
+// printPlannedRequests prints every request a dry run did not send, after the
+// command's own output (on stderr with -o json, so stdout stays valid JSON).
+// It also runs when the command failed, to show what was planned before.
+func printPlannedRequests() {
+	var planned []api.PlannedRequest
+	for _, c := range plannedClients {
+		planned = append(planned, c.Planned...)
+	}
+	if len(planned) == 0 {
+		return
+	}
+
+	var w io.Writer = os.Stdout
+	if getFormat() == output.FormatJSON {
+		w = os.Stderr
+	}
+	fmt.Fprintf(w, "\nDry run, %d request(s) not sent:\n", len(planned))
+	for _, r := range planned {
+		fmt.Fprintf(w, "%s %s\n", r.Method, r.URL)
+		if r.Body != nil {
+			if data, err := json.MarshalIndent(r.Body, "", "  "); err == nil {
+				fmt.Fprintln(w, string(data))
+			}
+		}
+	}
+}
+
+// previewUpdate prints, under --dry-run, the current and requested value of
+// every field an update sets. Fields are matched by JSON name, which the
+// update types share with the entities they modify.
+func previewUpdate(label string, before, update interface{}) {
+	current := toJSONMap(before)
+	changes := toJSONMap(update)
+
+	keys := make([]string, 0, len(changes))
+	for k := range changes {
+		if k != "id" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	fmt.Printf("%s:\n", label)
+	for _, k := range keys {
+		fmt.Printf("  %s: %s -> %s\n", k, previewValue(current[k]), previewValue(changes[k]))
+	}
+}
+
+func toJSONMap(v interface{}) map[string]interface{} {
+	m := map[string]interface{}{}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return m
+	}
+	_ = json.Unmarshal(data, &m)
+	return m
+}
+
+func previewValue(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return "(unset)"
+	case string:
+		return val
+	case map[string]interface{}:
+		if amount, ok := val["amount"]; ok {
+			return fmt.Sprintf("%v %v", amount, val["currency"])
+		}
+	}
+	data, _ := json.Marshal(v)
+	return string(data)
+}
@@ -0,0 +1,256 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/trebuhs/asa-cli/internal/config"
+	"github.com/trebuhs/asa-cli/internal/models"
+	"github.com/trebuhs/asa-cli/internal/output"
+	"github.com/trebuhs/asa-cli/internal/services"
+)
+
+var reportsImpressionShareCmd = &cobra.Command{
+	Use:   "impression-share",
+	Short: "Impression share (share of voice) report",
+	Long: `Request an impression share custom report and download its CSV.
+
+Reports are generated asynchronously. Without --wait the report is requested
+and its state printed; run the command again (or pass --id) to download it.
+A report requested with the same options on the same day is reused, and
+downloads are cached in ~/.asa-cli/reports.`,
+	RunE: runReportImpressionShare,
+}
+
+var (
+	isRange       string
+	isStartDate   string
+	isEndDate     string
+	isGranularity string
+	isCountries   string
+	isAppID       int64
+	isReportID    int64
+	isWait        bool
+	isTimeout     time.Duration
+	isOut         string
+)
+
+// impressionShareRanges maps --range values to the API's date ranges.
+var impressionShareRanges = map[string]string{
+	"last-7-days":  "LAST_WEEK",
+	"last-14-days": "LAST_2_WEEKS",
+	"last-28-days": "LAST_4_WEEKS",
+}
+
+func init() {
+	f := reportsImpressionShareCmd.Flags()
+	f.StringVar(&isRange, "range", "last-7-days", "Date range: last-7-days, last-14-days, last-28-days")
+	f.StringVar(&isStartDate, "start-date", "", "Start date (YYYY-MM-DD), instead of --range")
+	f.StringVar(&isEndDate, "end-date", "", "End date (YYYY-MM-DD), instead of --range")
+	f.StringVar(&isGranularity, "granularity", "DAILY", "Granularity: DAILY, WEEKLY")
+	f.StringVar(&isCountries, "countries", "", "Comma-separated country codes to include (default: all)")
+	f.Int64Var(&isAppID, "app-id", 0, "App Adam ID to include (default: all)")
+	f.Int64Var(&isReportID, "id", 0, "Use an existing report instead of requesting one")
+	f.BoolVar(&isWait, "wait", false, "Wait for the report to complete and download it")
+	f.DurationVar(&isTimeout, "timeout", 10*time.Minute, "How long --wait waits")
+	f.StringVar(&isOut, "out", "", "Write the CSV to this file instead of stdout")
+	reportsImpressionShareCmd.MarkFlagsRequiredTogether("start-date", "end-date")
+	reportsImpressionShareCmd.MarkFlagsMutuallyExclusive("range", "start-date")
+	reportsImpressionShareCmd.MarkFlagsMutuallyExclusive("id", "range")
+	reportsImpressionShareCmd.MarkFlagsMutuallyExclusive("id", "start-date")
+
+	reportsCmd.AddCommand(reportsImpressionShareCmd)
+}
+
+var impressionShareColumns = []output.Column{
+	{Header: "ID", Field: "ID", Width: 12},
+	{Header: "NAME", Field: "Name", Width: 30},
+	{Header: "STATE", Field: "State", Width: 10},
+	{Header: "RANGE", Field: "DateRange", Width: 12},
+	{Header: "START", Field: "StartTime", Width: 12},
+	{Header: "END", Field: "EndTime", Width: 12},
+	{Header: "GRANULARITY", Field: "Granularity", Width: 11},
+}
+
+func runReportImpressionShare(cmd *cobra.Command, args []string) error {
+	var req *models.ImpressionShareReportRequest
+	if isReportID == 0 {
+		var err error
+		if req, err = buildImpressionShareRequest(); err != nil {
+			return err
+		}
+	}
+
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	svc := services.NewReportingService(client)
+
+	var report *models.ImpressionShareReport
+	if isReportID != 0 {
+		if report, err = svc.GetImpressionShareReport(isReportID); err != nil {
+			return fmt.Errorf("getting impression share report: %w", err)
+		}
+	} else if report, err = findImpressionShareReport(svc, req.Name); err != nil {
+		return err
+	} else if report == nil {
+		if report, err = svc.CreateImpressionShareReport(req); err != nil {
+			return fmt.Errorf("requesting impression share report: %w", err)
+		}
+	}
+
+	if report.State != "COMPLETED" && isWait {
+		last := ""
+		report, err = svc.WaitForImpressionShareReport(report.ID, isTimeout, func(r *models.ImpressionShareReport) {
+			if r.State != last {
+				fmt.Fprintf(os.Stderr, "Report %d: %s\n", r.ID, r.State)
+				last = r.State
+			}
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if report.State != "COMPLETED" {
+		output.Print(getFormat(), report, impressionShareColumns)
+		if getFormat() == output.FormatTable {
+			fmt.Printf("\nReport %d is %s. Download it when it completes with:\n  asa-cli reports impression-share --id %d --wait\n", report.ID, report.State, report.ID)
+		}
+		return nil
+	}
+
+	data, err := impressionShareCSV(svc, report)
+	if err != nil {
+		return err
+	}
+	return printImpressionShare(data)
+}
+
+func buildImpressionShareRequest() (*models.ImpressionShareReportRequest, error) {
+	req := &models.ImpressionShareReportRequest{Granularity: strings.ToUpper(isGranularity)}
+	if req.Granularity != "DAILY" && req.Granularity != "WEEKLY" {
+		return nil, fmt.Errorf("invalid --granularity %q (expected DAILY or WEEKLY)", isGranularity)
+	}
+
+	if isStartDate != "" {
+		for flag, value := range map[string]string{"start-date": isStartDate, "end-date": isEndDate} {
+			if _, err := time.Parse("2006-01-02", value); err != nil {
+				return nil, fmt.Errorf("invalid --%s %q (expected YYYY-MM-DD)", flag, value)
+			}
+		}
+		req.DateRange, req.StartTime, req.EndTime = "CUSTOM", isStartDate, isEndDate
+	} else {
+		dateRange, ok := impressionShareRanges[strings.ToLower(isRange)]
+		if !ok {
+			return nil, fmt.Errorf("invalid --range %q (expected last-7-days, last-14-days or last-28-days)", isRange)
+		}
+		req.DateRange = dateRange
+	}
+
+	var conditions []models.Condition
+	if isCountries != "" {
+		conditions = append(conditions, models.Condition{Field: "countryOrRegion", Operator: "IN", Values: strings.Split(strings.ToUpper(isCountries), ",")})
+	}
+	if isAppID != 0 {
+		conditions = append(conditions, models.Condition{Field: "adamId", Operator: "IN", Values: []string{strconv.FormatInt(isAppID, 10)}})
+	}
+	if len(conditions) > 0 {
+		req.Selector = &models.CustomReportSelector{Conditions: conditions}
+	}
+
+	// The name identifies the options and the day, so a second run reuses
+	// the report instead of requesting another.
+	data, _ := json.Marshal(req)
+	sum := sha256.Sum256(data)
+	req.Name = fmt.Sprintf("asa-cli-is-%s-%s", time.Now().Format("20060102"), hex.EncodeToString(sum[:4]))
+	return req, nil
+}
+
+// findImpressionShareReport returns the most recent report with the given
+// name that has not failed, or nil.
+func findImpressionShareReport(svc *services.ReportingService, name string) (*models.ImpressionShareReport, error) {
+	reports, _, err := svc.ListImpressionShareReports(50, 0)
+	if err != nil {
+		return nil, fmt.Errorf("listing impression share reports: %w", err)
+	}
+	var found *models.ImpressionShareReport
+	for i := range reports {
+		r := &reports[i]
+		if r.Name == name && r.State != "FAILED" && (found == nil || r.ID > found.ID) {
+			found = r
+		}
+	}
+	return found, nil
+}
+
+// impressionShareCSV returns a completed report's CSV from the download
+// cache, downloading it on first use.
+func impressionShareCSV(svc *services.ReportingService, report *models.ImpressionShareReport) ([]byte, error) {
+	dir := filepath.Join(config.ConfigDir(), "reports")
+	path := filepath.Join(dir, fmt.Sprintf("impression-share-%d.csv", report.ID))
+	if data, err := os.ReadFile(path); err == nil {
+		return data, nil
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("reading cached report: %w", err)
+	}
+
+	data, err := svc.DownloadImpressionShareReport(report)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0700); err == nil {
+		if err := os.WriteFile(path, data, 0600); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not cache report: %v\n", err)
+		}
+	}
+	return data, nil
+}
+
+// printImpressionShare writes the CSV to --out or stdout; with -o json the
+// rows are printed as objects keyed by column.
+func printImpressionShare(data []byte) error {
+	if isOut != "" {
+		if err := os.WriteFile(isOut, data, 0644); err != nil {
+			return fmt.Errorf("writing %s: %w", isOut, err)
+		}
+		if getFormat() == output.FormatTable {
+			fmt.Printf("Saved impression share report to %s\n", isOut)
+		}
+		return nil
+	}
+
+	if getFormat() != output.FormatJSON {
+		os.Stdout.Write(data)
+		return nil
+	}
+
+	records, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(string(data), "\ufeff"))).ReadAll()
+	if err != nil {
+		return fmt.Errorf("parsing impression share report: %w", err)
+	}
+	rows := []map[string]string{}
+	for _, record := range records[min(1, len(records)):] {
+		row := map[string]string{}
+		for i, value := range record {
+			if i < len(records[0]) {
+				row[records[0][i]] = value
+			}
+		}
+		rows = append(rows, row)
+	}
+	output.Print(getFormat(), rows, nil)
+	return nil
+}
@@ -1,11 +1,16 @@
 package cmd
 
 import (
+	"bufio"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/trebuhs/asa-cli/internal/api"
 	"github.com/trebuhs/asa-cli/internal/models"
 	"github.com/trebuhs/asa-cli/internal/output"
 	"github.com/trebuhs/asa-cli/internal/services"
@@ -44,7 +49,12 @@ var kwCreateCmd = &cobra.Command{
 var kwUpdateCmd = &cobra.Command{
 	Use:   "update",
 	Short: "Update targeting keywords",
-	RunE:  runKWUpdate,
+	Long: `Update one keyword with --id, or many at once with --file or --stdin.
+
+--file reads a CSV (or .tsv) file with columns id, bid, status.
+--stdin reads newline-delimited JSON such as {"id": 123, "bid": "1.50", "status": "PAUSED"}.
+Rows are sent in bulk batches and a per-row result table is printed.`,
+	RunE: runKWUpdate,
 }
 
 var kwDeleteCmd = &cobra.Command{
@@ -54,6 +64,18 @@ var kwDeleteCmd = &cobra.Command{
 	RunE:  runKWDelete,
 }
 
+var kwImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Bulk create keywords from a CSV/TSV file",
+	Long: `Create targeting keywords from a CSV (or .tsv) file with a header row.
+
+Columns: text (required), match_type, bid, status, campaign_id, adgroup_id.
+Empty match_type, bid, campaign_id and adgroup_id fall back to the flags.
+Rows that duplicate an existing keyword or an earlier row are rejected
+before anything is sent; the rest are created in bulk batches.`,
+	RunE: runKWImport,
+}
+
 var (
 	kwCampaignID int64
 	kwAdGroupID  int64
@@ -67,6 +89,8 @@ var (
 	kwBid        string
 	kwStatus     string
 	kwID         int64
+	kwFile       string
+	kwStdin      bool
 )
 
 func init() {
@@ -96,12 +120,23 @@ func init() {
 	kwCreateCmd.MarkFlagRequired("text")
 
 	// update
-	kwUpdateCmd.Flags().Int64Var(&kwID, "id", 0, "Keyword ID to update (required)")
+	kwUpdateCmd.Flags().Int64Var(&kwID, "id", 0, "Keyword ID to update")
 	kwUpdateCmd.Flags().StringVar(&kwStatus, "status", "", "Status (ACTIVE/PAUSED)")
 	kwUpdateCmd.Flags().StringVar(&kwBid, "bid", "", "Bid amount")
-	kwUpdateCmd.MarkFlagRequired("id")
-
-	keywordsCmd.AddCommand(kwListCmd, kwGetCmd, kwFindCmd, kwCreateCmd, kwUpdateCmd, kwDeleteCmd)
+	kwUpdateCmd.Flags().StringVar(&kwFile, "file", "", "CSV/TSV file of updates (columns: id, bid, status)")
+	kwUpdateCmd.Flags().BoolVar(&kwStdin, "stdin", false, "Read NDJSON updates from stdin")
+	kwUpdateCmd.MarkFlagsOneRequired("id", "file", "stdin")
+	kwUpdateCmd.MarkFlagsMutuallyExclusive("id", "file", "stdin")
+
+	// import
+	kwImportCmd.Flags().StringVar(&kwFile, "file", "", "CSV or TSV file (required)")
+	kwImportCmd.Flags().Int64Var(&kwCampaignID, "campaign-id", 0, "Default campaign ID for rows without campaign_id")
+	kwImportCmd.Flags().Int64Var(&kwAdGroupID, "adgroup-id", 0, "Default ad group ID for rows without adgroup_id")
+	kwImportCmd.Flags().StringVar(&kwMatchType, "match-type", "BROAD", "Default match type: BROAD or EXACT")
+	kwImportCmd.Flags().StringVar(&kwBid, "bid", "", "Default bid amount")
+	kwImportCmd.MarkFlagRequired("file")
+
+	keywordsCmd.AddCommand(kwListCmd, kwGetCmd, kwFindCmd, kwCreateCmd, kwUpdateCmd, kwDeleteCmd, kwImportCmd)
 	rootCmd.AddCommand(keywordsCmd)
 }
 
@@ -189,12 +224,6 @@ func runKWCreate(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if kwBid != "" {
-		if err := checkBidLimit(kwBid); err != nil {
-			return err
-		}
-	}
-
 	var keywords []models.Keyword
 	for _, text := range kwTexts {
 		kw := models.Keyword{
@@ -223,14 +252,15 @@ func runKWUpdate(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if kwFile != "" || kwStdin {
+		return runKWBulkUpdate(client)
+	}
+
 	update := models.KeywordUpdate{ID: kwID}
 	if cmd.Flags().Changed("status") {
 		update.Status = kwStatus
 	}
 	if cmd.Flags().Changed("bid") {
-		if err := checkBidLimit(kwBid); err != nil {
-			return err
-		}
 		currency, err := resolveOrgCurrency(client)
 		if err != nil {
 			return err
@@ -239,6 +269,14 @@ func runKWUpdate(cmd *cobra.Command, args []string) error {
 	}
 
 	svc := services.NewKeywordService(client)
+	if dryRun {
+		before, err := svc.Get(kwCampaignID, kwAdGroupID, kwID)
+		if err != nil {
+			return fmt.Errorf("getting keyword: %w", err)
+		}
+		previewUpdate(fmt.Sprintf("Keyword %d", kwID), before, update)
+	}
+
 	updated, err := svc.Update(kwCampaignID, kwAdGroupID, []models.KeywordUpdate{update})
 	if err != nil {
 		return fmt.Errorf("updating keyword: %w", err)
@@ -264,6 +302,28 @@ func runKWDelete(cmd *cobra.Command, args []string) error {
 	}
 
 	svc := services.NewKeywordService(client)
+	selector := models.NewSelector(1000, 0)
+	selector.Conditions = []models.Condition{idCondition(ids)}
+	found, err := svc.FindAll(kwCampaignID, kwAdGroupID, selector)
+	if err != nil {
+		return fmt.Errorf("fetching keywords: %w", err)
+	}
+	details := make([]string, 0, len(ids))
+	byID := map[int64]models.Keyword{}
+	for _, kw := range found {
+		byID[kw.ID] = kw
+	}
+	for _, id := range ids {
+		if kw, ok := byID[id]; ok {
+			details = append(details, fmt.Sprintf("Keyword %d %q (%s, status: %s)", kw.ID, kw.Text, kw.MatchType, kw.Status))
+		} else {
+			details = append(details, fmt.Sprintf("Keyword %d (not found in ad group %d)", id, kwAdGroupID))
+		}
+	}
+	if err := confirmDestructive(fmt.Sprintf("delete %d keyword(s)", len(ids)), details); err != nil {
+		return err
+	}
+
 	if err := svc.Delete(kwCampaignID, kwAdGroupID, ids); err != nil {
 		return fmt.Errorf("deleting keywords: %w", err)
 	}
@@ -271,3 +331,355 @@ func runKWDelete(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Deleted %d keyword(s).\n", len(ids))
 	return nil
 }
+
+// kwImportRow is a parsed import row waiting to be sent.
+type kwImportRow struct {
+	result  *bulkResult
+	keyword models.Keyword
+}
+
+func (r kwImportRow) outcome() *bulkResult { return r.result }
+
+func runKWImport(cmd *cobra.Command, args []string) error {
+	records, err := readRecords(kwFile)
+	if err != nil {
+		return err
+	}
+
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	currency, err := resolveOrgCurrency(client)
+	if err != nil {
+		return err
+	}
+
+	rowLimits := limits()
+	results := make([]bulkResult, len(records))
+	type target struct{ campaignID, adGroupID int64 }
+	groups := map[target][]kwImportRow{}
+	var order []target
+
+	for i, rec := range records {
+		res := &results[i]
+		res.Row = rec.Line
+		res.Text = rec.get("text")
+		res.MatchType = strings.ToUpper(rec.get("match_type"))
+		if res.MatchType == "" {
+			res.MatchType = strings.ToUpper(kwMatchType)
+		}
+		res.Result = "rejected"
+
+		campaignID, err := parseOptionalID(rec.get("campaign_id"), kwCampaignID)
+		if err != nil {
+			res.Reason = "campaign_id: " + err.Error()
+			continue
+		}
+		adGroupID, err := parseOptionalID(rec.get("adgroup_id"), kwAdGroupID)
+		if err != nil {
+			res.Reason = "adgroup_id: " + err.Error()
+			continue
+		}
+		res.AdGroupID = adGroupID
+
+		switch {
+		case res.Text == "":
+			res.Reason = "text is empty"
+			continue
+		case campaignID == 0 || adGroupID == 0:
+			res.Reason = "no campaign_id/adgroup_id (set the columns or --campaign-id/--adgroup-id)"
+			continue
+		case res.MatchType != "BROAD" && res.MatchType != "EXACT":
+			res.Reason = fmt.Sprintf("invalid match type %q", res.MatchType)
+			continue
+		}
+
+		kw := models.Keyword{
+			Text:      res.Text,
+			MatchType: res.MatchType,
+			Status:    strings.ToUpper(rec.get("status")),
+		}
+		bid := rec.get("bid")
+		if bid == "" {
+			bid = kwBid
+		}
+		if bid != "" {
+			if err := rowLimits.Bid(bid); err != nil {
+				res.Reason = err.Error()
+				continue
+			}
+			kw.BidAmount = &models.Money{Amount: bid, Currency: currency}
+		}
+
+		t := target{campaignID, adGroupID}
+		if _, ok := groups[t]; !ok {
+			order = append(order, t)
+		}
+		groups[t] = append(groups[t], kwImportRow{result: res, keyword: kw})
+	}
+
+	// Duplicates are dropped before the change count is checked, so only
+	// keywords that will be created count against it.
+	svc := services.NewKeywordService(client)
+	pending := map[target][]kwImportRow{}
+	queued := 0
+	for _, t := range order {
+		existing, err := svc.FindAll(t.campaignID, t.adGroupID, models.NewSelector(1000, 0))
+		if err != nil {
+			return fmt.Errorf("fetching existing keywords for ad group %d: %w", t.adGroupID, err)
+		}
+		seen := map[string]string{}
+		for _, kw := range existing {
+			if !kw.Deleted {
+				seen[models.Key(kw.Text, kw.MatchType)] = fmt.Sprintf("already exists (ID %d)", kw.ID)
+			}
+		}
+
+		for _, row := range groups[t] {
+			key := models.Key(row.keyword.Text, row.keyword.MatchType)
+			if reason, dup := seen[key]; dup {
+				row.result.Reason = reason
+				continue
+			}
+			seen[key] = fmt.Sprintf("duplicate of row %d", row.result.Row)
+			pending[t] = append(pending[t], row)
+			queued++
+		}
+	}
+	if err := checkChangeCount(client, queued); err != nil {
+		return err
+	}
+
+	for _, t := range order {
+		for _, batch := range services.Chunk(pending[t], services.KeywordBulkLimit) {
+			createKeywordBatch(svc, t.campaignID, t.adGroupID, batch)
+		}
+	}
+
+	return printBulkResults(results, "created")
+}
+
+// createKeywordBatch creates one bulk batch and records per-row results.
+func createKeywordBatch(svc *services.KeywordService, campaignID, adGroupID int64, batch []kwImportRow) {
+	sendBulk(batch, "rejected", func(rows []kwImportRow) error {
+		keywords := make([]models.Keyword, len(rows))
+		for i, row := range rows {
+			keywords[i] = row.keyword
+		}
+		created, err := svc.Create(campaignID, adGroupID, keywords)
+		if err != nil {
+			return err
+		}
+		for i, row := range rows {
+			row.result.Result = "created"
+			if i < len(created) {
+				row.result.ID = created[i].ID
+			}
+		}
+		return nil
+	})
+}
+
+// kwUpdateInput is one row of --file or --stdin input.
+type kwUpdateInput struct {
+	line   int
+	id     string
+	bid    string
+	status string
+	err    string
+}
+
+// kwUpdateRow is a validated update waiting to be sent.
+type kwUpdateRow struct {
+	result *bulkResult
+	update models.KeywordUpdate
+}
+
+func (r kwUpdateRow) outcome() *bulkResult { return r.result }
+
+func runKWBulkUpdate(client *api.Client) error {
+	var inputs []kwUpdateInput
+	var err error
+	if kwStdin {
+		inputs, err = readNDJSONUpdates(os.Stdin)
+	} else {
+		inputs, err = readCSVUpdates(kwFile)
+	}
+	if err != nil {
+		return err
+	}
+
+	currency, err := resolveOrgCurrency(client)
+	if err != nil {
+		return err
+	}
+
+	rowLimits := limits()
+	results := make([]bulkResult, len(inputs))
+	var rows []kwUpdateRow
+	for i, in := range inputs {
+		res := &results[i]
+		res.Row = in.line
+		res.AdGroupID = kwAdGroupID
+		res.Result = "failed"
+		if in.err != "" {
+			res.Reason = in.err
+			continue
+		}
+
+		id, err := strconv.ParseInt(in.id, 10, 64)
+		if err != nil || id <= 0 {
+			res.Reason = fmt.Sprintf("invalid keyword ID %q", in.id)
+			continue
+		}
+		res.ID = id
+
+		update := models.KeywordUpdate{ID: id, Status: strings.ToUpper(in.status)}
+		if in.bid != "" {
+			if err := rowLimits.Bid(in.bid); err != nil {
+				res.Reason = err.Error()
+				continue
+			}
+			update.BidAmount = &models.Money{Amount: in.bid, Currency: currency}
+		}
+		if update.Status == "" && update.BidAmount == nil {
+			res.Reason = "nothing to update (no bid or status)"
+			continue
+		}
+		rows = append(rows, kwUpdateRow{result: res, update: update})
+	}
+
+	svc := services.NewKeywordService(client)
+	existing, err := svc.FindAll(kwCampaignID, kwAdGroupID, models.NewSelector(1000, 0))
+	if err != nil {
+		return fmt.Errorf("fetching keywords: %w", err)
+	}
+	current := map[int64]models.Keyword{}
+	for _, kw := range existing {
+		current[kw.ID] = kw
+	}
+
+	rows = changedKeywordUpdates(rows, current)
+	if err := checkChangeCount(client, len(rows)); err != nil {
+		return err
+	}
+	if dryRun {
+		previewKeywordUpdates(rows, current)
+	}
+
+	for _, batch := range services.Chunk(rows, services.KeywordBulkLimit) {
+		sendBulk(batch, "failed", func(rows []kwUpdateRow) error {
+			updates := make([]models.KeywordUpdate, len(rows))
+			for i, row := range rows {
+				updates[i] = row.update
+			}
+			updated, err := svc.Update(kwCampaignID, kwAdGroupID, updates)
+			if err != nil {
+				return err
+			}
+			byID := map[int64]models.Keyword{}
+			for _, kw := range updated {
+				byID[kw.ID] = kw
+			}
+			for _, row := range rows {
+				row.result.Result = "updated"
+				if kw, ok := byID[row.update.ID]; ok {
+					row.result.Text = kw.Text
+					row.result.MatchType = kw.MatchType
+				}
+			}
+			return nil
+		})
+	}
+
+	return printBulkResults(results, "updated")
+}
+
+// changedKeywordUpdates drops rows that repeat an earlier row's keyword ID
+// and rows that would not change the keyword, marking their results.
+func changedKeywordUpdates(rows []kwUpdateRow, current map[int64]models.Keyword) []kwUpdateRow {
+	var changed []kwUpdateRow
+	seen := map[int64]int{}
+	for _, row := range rows {
+		id := row.update.ID
+		if line, dup := seen[id]; dup {
+			row.result.Reason = fmt.Sprintf("duplicate of row %d", line)
+			continue
+		}
+		seen[id] = row.result.Row
+
+		if kw, ok := current[id]; ok && keywordUpdateIsNoop(row.update, kw) {
+			row.result.Result = resultUnchanged
+			row.result.Text, row.result.MatchType = kw.Text, kw.MatchType
+			continue
+		}
+		changed = append(changed, row)
+	}
+	return changed
+}
+
+// keywordUpdateIsNoop reports whether an update sets only values the keyword
+// already has.
+func keywordUpdateIsNoop(u models.KeywordUpdate, kw models.Keyword) bool {
+	if u.Status != "" && u.Status != kw.Status {
+		return false
+	}
+	if u.BidAmount != nil && (kw.BidAmount == nil || u.BidAmount.Float() != kw.BidAmount.Float()) {
+		return false
+	}
+	return true
+}
+
+// previewKeywordUpdates prints the before/after of every row for --dry-run.
+func previewKeywordUpdates(rows []kwUpdateRow, current map[int64]models.Keyword) {
+	for _, row := range rows {
+		before, ok := current[row.update.ID]
+		if !ok {
+			fmt.Printf("Keyword %d: not found in ad group %d\n", row.update.ID, kwAdGroupID)
+			continue
+		}
+		previewUpdate(fmt.Sprintf("Keyword %d (%s)", before.ID, before.Text), before, row.update)
+	}
+}
+
+func readCSVUpdates(path string) ([]kwUpdateInput, error) {
+	records, err := readRecords(path)
+	if err != nil {
+		return nil, err
+	}
+	inputs := make([]kwUpdateInput, len(records))
+	for i, rec := range records {
+		inputs[i] = kwUpdateInput{line: rec.Line, id: rec.get("id"), bid: rec.get("bid"), status: rec.get("status")}
+	}
+	return inputs, nil
+}
+
+// readNDJSONUpdates reads one JSON object per line; id and bid may be numbers or strings.
+func readNDJSONUpdates(r io.Reader) ([]kwUpdateInput, error) {
+	var inputs []kwUpdateInput
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var row struct {
+			ID     json.Number `json:"id"`
+			Bid    json.Number `json:"bid"`
+			Status string      `json:"status"`
+		}
+		if err := json.Unmarshal([]byte(text), &row); err != nil {
+			inputs = append(inputs, kwUpdateInput{line: line, err: "invalid JSON: " + err.Error()})
+			continue
+		}
+		inputs = append(inputs, kwUpdateInput{line: line, id: row.ID.String(), bid: row.Bid.String(), status: row.Status})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading stdin: %w", err)
+	}
+	return inputs, nil
+}
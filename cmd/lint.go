@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/trebuhs/asa-cli/internal/lint"
+	"github.com/trebuhs/asa-cli/internal/models"
+	"github.com/trebuhs/asa-cli/internal/output"
+	"github.com/trebuhs/asa-cli/internal/services"
+	"github.com/trebuhs/asa-cli/internal/state"
+)
+
+var lintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Check the account for structural problems",
+	Long: `Check campaigns and ad groups against the rules of a lint policy and exit
+with status 1 if any are violated.
+
+The policy is a YAML file (default ~/.asa-cli/lint-policy.yaml). Rules it does
+not mention keep their defaults; naming and country rules are off by default:
+
+  campaign_name: '^(Brand|Generic|Competitor|Discovery) - [A-Z]{2}$'
+  ad_group_name: '^(Exact|Broad|Search Match)$'
+  countries: single              # or "multiple"
+  require_default_bid: true
+  no_search_match_in_exact: true # search match in all-EXACT campaigns
+  not_running: true              # enabled campaigns that are not serving
+  min_budget_bid_ratio: 10       # daily budget >= highest bid x 10`,
+	RunE: runLint,
+}
+
+var lintKeywordsCmd = &cobra.Command{
+	Use:   "keywords",
+	Short: "Find overlapping, un-negated and blocked keywords",
+	Long: `Scan the targeting keywords of enabled campaigns and ad groups and report:
+
+  duplicate-keyword        the same text and match type in several ad groups
+                           serving the same app and storefront
+  missing-exact-negative   EXACT keywords that a search match or BROAD ad group
+                           for the same app and storefront does not negate
+  blocked-by-negative      active keywords blocked by a campaign or ad group
+                           negative keyword`,
+	RunE: runLintKeywords,
+}
+
+var (
+	lintCampaigns []string
+	lintPolicy    string
+)
+
+func init() {
+	lintCmd.PersistentFlags().StringSliceVar(&lintCampaigns, "campaigns", nil, `Campaign filter, same syntax as --filter (e.g. "name~Brand") (default: all campaigns)`)
+	lintCmd.Flags().StringVar(&lintPolicy, "policy", "", "Lint policy YAML file (default: ~/.asa-cli/lint-policy.yaml)")
+
+	lintCmd.AddCommand(lintKeywordsCmd)
+	rootCmd.AddCommand(lintCmd)
+}
+
+// fetchLintAccount loads the campaigns matching --campaigns with everything
+// nested under them.
+func fetchLintAccount() (*state.Account, error) {
+	client, err := newAPIClient()
+	if err != nil {
+		return nil, err
+	}
+
+	var include func(models.Campaign) bool
+	if len(lintCampaigns) > 0 {
+		selector := models.NewSelector(1000, 0)
+		selector.Conditions = parseFilters(lintCampaigns)
+		matched, err := services.NewCampaignService(client).FindAll(selector)
+		if err != nil {
+			return nil, fmt.Errorf("finding campaigns: %w", err)
+		}
+		ids := map[int64]bool{}
+		for _, c := range matched {
+			ids[c.ID] = true
+		}
+		include = func(c models.Campaign) bool { return ids[c.ID] }
+	}
+
+	account, err := state.Fetch(client, include)
+	if err != nil {
+		return nil, fmt.Errorf("fetching account: %w", err)
+	}
+	return account, nil
+}
+
+func runLint(cmd *cobra.Command, args []string) error {
+	path, optional := lintPolicy, false
+	if path == "" {
+		path, optional = lint.DefaultPolicyPath(), true
+	}
+	policy, err := lint.LoadPolicy(path, optional)
+	if err != nil {
+		return err
+	}
+
+	account, err := fetchLintAccount()
+	if err != nil {
+		return err
+	}
+	return printFindings(lint.Structure(account, policy))
+}
+
+func runLintKeywords(cmd *cobra.Command, args []string) error {
+	account, err := fetchLintAccount()
+	if err != nil {
+		return err
+	}
+	return printFindings(lint.Keywords(account))
+}
+
+// printFindings prints findings grouped by rule, each with its fix, and
+// returns an error when there are any so the command exits non-zero.
+func printFindings(findings []lint.Finding) error {
+	if getFormat() == output.FormatJSON {
+		if findings == nil {
+			findings = []lint.Finding{}
+		}
+		output.Print(getFormat(), findings, nil)
+	} else if len(findings) == 0 {
+		fmt.Println("No problems found.")
+	} else {
+		printFindingsTable(findings)
+	}
+
+	if len(findings) > 0 {
+		return fmt.Errorf("%d problem(s) found", len(findings))
+	}
+	return nil
+}
+
+func printFindingsTable(findings []lint.Finding) {
+
+	counts := map[string]int{}
+	for _, f := range findings {
+		counts[f.Rule]++
+	}
+	rule := ""
+	for _, f := range findings {
+		if f.Rule != rule {
+			if rule != "" {
+				fmt.Println()
+			}
+			rule = f.Rule
+			fmt.Printf("%s (%d)\n", strings.ToUpper(rule), counts[rule])
+		}
+		fmt.Printf("  %s\n", f.Subject)
+		for _, w := range f.Where {
+			fmt.Printf("    - %s\n", w)
+		}
+		fmt.Printf("    fix: %s\n", f.Fix)
+	}
+	fmt.Println()
+}
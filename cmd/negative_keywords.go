@@ -36,6 +36,20 @@ var nkCampaignFindCmd = &cobra.Command{
 	RunE:  runNKCampaignFind,
 }
 
+var nkCampaignGetCmd = &cobra.Command{
+	Use:   "campaign-get <id>",
+	Short: "Get a campaign-level negative keyword by ID",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runNKCampaignGet,
+}
+
+var nkCampaignUpdateCmd = &cobra.Command{
+	Use:   "campaign-update <id,...>",
+	Short: "Update the status of campaign-level negative keywords",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runNKCampaignUpdate,
+}
+
 var nkCampaignDeleteCmd = &cobra.Command{
 	Use:   "campaign-delete <id,...>",
 	Short: "Delete campaign-level negative keywords",
@@ -63,6 +77,20 @@ var nkAdGroupFindCmd = &cobra.Command{
 	RunE:  runNKAdGroupFind,
 }
 
+var nkAdGroupGetCmd = &cobra.Command{
+	Use:   "adgroup-get <id>",
+	Short: "Get an ad-group-level negative keyword by ID",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runNKAdGroupGet,
+}
+
+var nkAdGroupUpdateCmd = &cobra.Command{
+	Use:   "adgroup-update <id,...>",
+	Short: "Update the status of ad-group-level negative keywords",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runNKAdGroupUpdate,
+}
+
 var nkAdGroupDeleteCmd = &cobra.Command{
 	Use:   "adgroup-delete <id,...>",
 	Short: "Delete ad-group-level negative keywords",
@@ -79,11 +107,12 @@ var (
 	nkMatchType  string
 	nkFilters    []string
 	nkSorts      []string
+	nkStatus     string
 )
 
 func init() {
 	// Campaign-level commands
-	for _, cmd := range []*cobra.Command{nkCampaignListCmd, nkCampaignCreateCmd, nkCampaignFindCmd, nkCampaignDeleteCmd} {
+	for _, cmd := range []*cobra.Command{nkCampaignListCmd, nkCampaignCreateCmd, nkCampaignFindCmd, nkCampaignGetCmd, nkCampaignUpdateCmd, nkCampaignDeleteCmd} {
 		cmd.Flags().Int64Var(&nkCampaignID, "campaign-id", 0, "Campaign ID (required)")
 		cmd.MarkFlagRequired("campaign-id")
 	}
@@ -100,8 +129,11 @@ func init() {
 	nkCampaignFindCmd.Flags().IntVar(&nkLimit, "limit", 20, "Number of results")
 	nkCampaignFindCmd.Flags().IntVar(&nkOffset, "offset", 0, "Results offset")
 
+	nkCampaignUpdateCmd.Flags().StringVar(&nkStatus, "status", "", "Status: ACTIVE or PAUSED (required)")
+	nkCampaignUpdateCmd.MarkFlagRequired("status")
+
 	// Ad group-level commands
-	for _, cmd := range []*cobra.Command{nkAdGroupListCmd, nkAdGroupCreateCmd, nkAdGroupFindCmd, nkAdGroupDeleteCmd} {
+	for _, cmd := range []*cobra.Command{nkAdGroupListCmd, nkAdGroupCreateCmd, nkAdGroupFindCmd, nkAdGroupGetCmd, nkAdGroupUpdateCmd, nkAdGroupDeleteCmd} {
 		cmd.Flags().Int64Var(&nkCampaignID, "campaign-id", 0, "Campaign ID (required)")
 		cmd.Flags().Int64Var(&nkAdGroupID, "adgroup-id", 0, "Ad group ID (required)")
 		cmd.MarkFlagRequired("campaign-id")
@@ -120,9 +152,12 @@ func init() {
 	nkAdGroupFindCmd.Flags().IntVar(&nkLimit, "limit", 20, "Number of results")
 	nkAdGroupFindCmd.Flags().IntVar(&nkOffset, "offset", 0, "Results offset")
 
+	nkAdGroupUpdateCmd.Flags().StringVar(&nkStatus, "status", "", "Status: ACTIVE or PAUSED (required)")
+	nkAdGroupUpdateCmd.MarkFlagRequired("status")
+
 	negKeywordsCmd.AddCommand(
-		nkCampaignListCmd, nkCampaignCreateCmd, nkCampaignFindCmd, nkCampaignDeleteCmd,
-		nkAdGroupListCmd, nkAdGroupCreateCmd, nkAdGroupFindCmd, nkAdGroupDeleteCmd,
+		nkCampaignListCmd, nkCampaignCreateCmd, nkCampaignFindCmd, nkCampaignGetCmd, nkCampaignUpdateCmd, nkCampaignDeleteCmd,
+		nkAdGroupListCmd, nkAdGroupCreateCmd, nkAdGroupFindCmd, nkAdGroupGetCmd, nkAdGroupUpdateCmd, nkAdGroupDeleteCmd,
 	)
 	rootCmd.AddCommand(negKeywordsCmd)
 }
@@ -196,6 +231,62 @@ func runNKCampaignFind(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runNKCampaignGet(cmd *cobra.Command, args []string) error {
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid negative keyword ID: %s", args[0])
+	}
+
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	svc := services.NewKeywordService(client)
+	keyword, err := svc.GetCampaignNegativeKeyword(nkCampaignID, id)
+	if err != nil {
+		return fmt.Errorf("getting negative keyword: %w", err)
+	}
+
+	output.Print(getFormat(), keyword, negKeywordColumns)
+	return nil
+}
+
+func runNKCampaignUpdate(cmd *cobra.Command, args []string) error {
+	updates, err := negativeUpdates(args[0])
+	if err != nil {
+		return err
+	}
+
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	svc := services.NewKeywordService(client)
+	var updated []models.NegativeKeyword
+	for _, batch := range services.Chunk(updates, services.KeywordBulkLimit) {
+		if dryRun {
+			selector := models.NewSelector(1000, 0)
+			selector.Conditions = []models.Condition{idCondition(updateIDs(batch))}
+			found, err := svc.FindAllCampaignNegativeKeywords(nkCampaignID, selector)
+			if err != nil {
+				return fmt.Errorf("fetching negative keywords: %w", err)
+			}
+			previewNegativeUpdates(batch, found)
+		}
+
+		result, err := svc.UpdateCampaignNegativeKeywords(nkCampaignID, batch)
+		if err != nil {
+			return fmt.Errorf("updating negative keywords: %w", err)
+		}
+		updated = append(updated, result...)
+	}
+
+	output.Print(getFormat(), updated, negKeywordColumns)
+	return nil
+}
+
 func runNKCampaignDelete(cmd *cobra.Command, args []string) error {
 	client, err := newAPIClient()
 	if err != nil {
@@ -208,6 +299,16 @@ func runNKCampaignDelete(cmd *cobra.Command, args []string) error {
 	}
 
 	svc := services.NewKeywordService(client)
+	selector := models.NewSelector(1000, 0)
+	selector.Conditions = []models.Condition{idCondition(ids)}
+	found, err := svc.FindAllCampaignNegativeKeywords(nkCampaignID, selector)
+	if err != nil {
+		return fmt.Errorf("fetching negative keywords: %w", err)
+	}
+	if err := confirmDestructive(fmt.Sprintf("delete %d campaign negative keyword(s)", len(ids)), describeNegatives(ids, found)); err != nil {
+		return err
+	}
+
 	if err := svc.DeleteCampaignNegativeKeywords(nkCampaignID, ids); err != nil {
 		return fmt.Errorf("deleting negative keywords: %w", err)
 	}
@@ -278,6 +379,62 @@ func runNKAdGroupFind(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runNKAdGroupGet(cmd *cobra.Command, args []string) error {
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid negative keyword ID: %s", args[0])
+	}
+
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	svc := services.NewKeywordService(client)
+	keyword, err := svc.GetAdGroupNegativeKeyword(nkCampaignID, nkAdGroupID, id)
+	if err != nil {
+		return fmt.Errorf("getting negative keyword: %w", err)
+	}
+
+	output.Print(getFormat(), keyword, negKeywordColumns)
+	return nil
+}
+
+func runNKAdGroupUpdate(cmd *cobra.Command, args []string) error {
+	updates, err := negativeUpdates(args[0])
+	if err != nil {
+		return err
+	}
+
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	svc := services.NewKeywordService(client)
+	var updated []models.NegativeKeyword
+	for _, batch := range services.Chunk(updates, services.KeywordBulkLimit) {
+		if dryRun {
+			selector := models.NewSelector(1000, 0)
+			selector.Conditions = []models.Condition{idCondition(updateIDs(batch))}
+			found, err := svc.FindAllAdGroupNegativeKeywords(nkCampaignID, nkAdGroupID, selector)
+			if err != nil {
+				return fmt.Errorf("fetching negative keywords: %w", err)
+			}
+			previewNegativeUpdates(batch, found)
+		}
+
+		result, err := svc.UpdateAdGroupNegativeKeywords(nkCampaignID, nkAdGroupID, batch)
+		if err != nil {
+			return fmt.Errorf("updating negative keywords: %w", err)
+		}
+		updated = append(updated, result...)
+	}
+
+	output.Print(getFormat(), updated, negKeywordColumns)
+	return nil
+}
+
 func runNKAdGroupDelete(cmd *cobra.Command, args []string) error {
 	client, err := newAPIClient()
 	if err != nil {
@@ -290,6 +447,16 @@ func runNKAdGroupDelete(cmd *cobra.Command, args []string) error {
 	}
 
 	svc := services.NewKeywordService(client)
+	selector := models.NewSelector(1000, 0)
+	selector.Conditions = []models.Condition{idCondition(ids)}
+	found, err := svc.FindAllAdGroupNegativeKeywords(nkCampaignID, nkAdGroupID, selector)
+	if err != nil {
+		return fmt.Errorf("fetching negative keywords: %w", err)
+	}
+	if err := confirmDestructive(fmt.Sprintf("delete %d ad group negative keyword(s)", len(ids)), describeNegatives(ids, found)); err != nil {
+		return err
+	}
+
 	if err := svc.DeleteAdGroupNegativeKeywords(nkCampaignID, nkAdGroupID, ids); err != nil {
 		return fmt.Errorf("deleting negative keywords: %w", err)
 	}
@@ -298,6 +465,66 @@ func runNKAdGroupDelete(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// describeNegatives lists each ID with the negative keyword it refers to.
+func describeNegatives(ids []int64, found []models.NegativeKeyword) []string {
+	byID := map[int64]models.NegativeKeyword{}
+	for _, nk := range found {
+		byID[nk.ID] = nk
+	}
+	details := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if nk, ok := byID[id]; ok {
+			details = append(details, fmt.Sprintf("Negative keyword %d %q (%s, status: %s)", nk.ID, nk.Text, nk.MatchType, nk.Status))
+		} else {
+			details = append(details, fmt.Sprintf("Negative keyword %d (not found)", id))
+		}
+	}
+	return details
+}
+
+// negativeUpdates builds a status update for each ID in a comma-separated
+// list.
+func negativeUpdates(idList string) ([]models.NegativeKeywordUpdate, error) {
+	status := strings.ToUpper(nkStatus)
+	if status != "ACTIVE" && status != "PAUSED" {
+		return nil, fmt.Errorf("invalid status %q (expected ACTIVE or PAUSED)", nkStatus)
+	}
+	ids, err := parseIDList(idList)
+	if err != nil {
+		return nil, err
+	}
+	updates := make([]models.NegativeKeywordUpdate, len(ids))
+	for i, id := range ids {
+		updates[i] = models.NegativeKeywordUpdate{ID: id, Status: status}
+	}
+	return updates, nil
+}
+
+func updateIDs(updates []models.NegativeKeywordUpdate) []int64 {
+	ids := make([]int64, len(updates))
+	for i, u := range updates {
+		ids[i] = u.ID
+	}
+	return ids
+}
+
+// previewNegativeUpdates prints, under --dry-run, the current and new status
+// of each negative keyword.
+func previewNegativeUpdates(updates []models.NegativeKeywordUpdate, found []models.NegativeKeyword) {
+	byID := map[int64]models.NegativeKeyword{}
+	for _, nk := range found {
+		byID[nk.ID] = nk
+	}
+	for _, u := range updates {
+		before, ok := byID[u.ID]
+		if !ok {
+			fmt.Printf("Negative keyword %d: not found\n", u.ID)
+			continue
+		}
+		previewUpdate(fmt.Sprintf("Negative keyword %d (%s)", before.ID, before.Text), before, u)
+	}
+}
+
 func parseIDList(s string) ([]int64, error) {
 	var ids []int64
 	for _, part := range strings.Split(s, ",") {
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/trebuhs/asa-cli/internal/models"
+	"github.com/trebuhs/asa-cli/internal/negatives"
+	"github.com/trebuhs/asa-cli/internal/output"
+	"github.com/trebuhs/asa-cli/internal/services"
+)
+
+var nkSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Sync shared negative keyword lists to campaigns",
+	Long: `Add the negatives of one or more named lists to every matching campaign
+that lacks them. With --remove, campaign-level negatives that are in none of
+the lists are deleted.
+
+Lists live in a YAML file (default ~/.asa-cli/negative-lists.yaml):
+
+  brand-blocks:
+    - free
+    - text: cheap
+      match_type: BROAD
+  competitors:
+    - othertracker`,
+	RunE: runNKSync,
+}
+
+var (
+	nkSyncLists     []string
+	nkSyncCampaigns []string
+	nkSyncRemove    bool
+	nkSyncFile      string
+)
+
+func init() {
+	nkSyncCmd.Flags().StringSliceVar(&nkSyncLists, "list", nil, "List name (repeatable) (required)")
+	nkSyncCmd.Flags().StringSliceVar(&nkSyncCampaigns, "campaigns", nil, `Campaign filter, same syntax as --filter (e.g. "name~Brand") (default: all campaigns)`)
+	nkSyncCmd.Flags().BoolVar(&nkSyncRemove, "remove", false, "Delete campaign negatives that are not in the lists")
+	nkSyncCmd.Flags().StringVar(&nkSyncFile, "lists-file", "", "Negative lists YAML file (default: ~/.asa-cli/negative-lists.yaml)")
+	nkSyncCmd.MarkFlagRequired("list")
+
+	negKeywordsCmd.AddCommand(nkSyncCmd)
+}
+
+type nkSyncRow struct {
+	CampaignID int64  `json:"campaignId"`
+	Campaign   string `json:"campaign"`
+	Add        int    `json:"add"`
+	Remove     int    `json:"remove"`
+
+	add []models.NegativeKeyword
+	del []models.NegativeKeyword
+}
+
+func runNKSync(cmd *cobra.Command, args []string) error {
+	path := nkSyncFile
+	if path == "" {
+		path = negatives.DefaultPath()
+	}
+	lists, err := negatives.Load(path)
+	if err != nil {
+		return err
+	}
+	want, err := lists.Resolve(nkSyncLists)
+	if err != nil {
+		return err
+	}
+
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	selector := models.NewSelector(1000, 0)
+	selector.Conditions = parseFilters(nkSyncCampaigns)
+	campaigns, err := services.NewCampaignService(client).FindAll(selector)
+	if err != nil {
+		return fmt.Errorf("finding campaigns: %w", err)
+	}
+
+	svc := services.NewKeywordService(client)
+	var rows []*nkSyncRow
+	changes := 0
+	var removals []string
+	for _, c := range campaigns {
+		if c.Deleted {
+			continue
+		}
+		existing, err := svc.FindAllCampaignNegativeKeywords(c.ID, models.NewSelector(1000, 0))
+		if err != nil {
+			return fmt.Errorf("fetching negative keywords for campaign %d: %w", c.ID, err)
+		}
+		add, del := negatives.Diff(want, existing, nkSyncRemove)
+		rows = append(rows, &nkSyncRow{CampaignID: c.ID, Campaign: c.Name, Add: len(add), Remove: len(del), add: add, del: del})
+		changes += len(add) + len(del)
+		for _, nk := range del {
+			removals = append(removals, fmt.Sprintf("Campaign %q: negative keyword %d %q (%s)", c.Name, nk.ID, nk.Text, nk.MatchType))
+		}
+	}
+
+	output.Print(getFormat(), rows, []output.Column{
+		{Header: "CAMPAIGN ID", Field: "CampaignID", Width: 12},
+		{Header: "CAMPAIGN", Field: "Campaign", Width: 30},
+		{Header: "ADD", Field: "Add", Width: 6},
+		{Header: "REMOVE", Field: "Remove", Width: 6},
+	})
+	if changes == 0 {
+		if getFormat() == output.FormatTable {
+			fmt.Println("All campaigns are in sync.")
+		}
+		return nil
+	}
+
+	if err := checkChangeCount(client, changes); err != nil {
+		return err
+	}
+	if len(removals) > 0 {
+		if err := confirmDestructive(fmt.Sprintf("delete %d campaign negative keyword(s) not in the lists", len(removals)), removals); err != nil {
+			return err
+		}
+	}
+
+	// A failing campaign does not stop the others; failures are reported
+	// per campaign at the end.
+	synced, failed := 0, 0
+	for _, row := range rows {
+		if row.Add == 0 && row.Remove == 0 {
+			continue
+		}
+		if err := syncCampaignNegatives(svc, row); err != nil {
+			fmt.Fprintf(os.Stderr, "Campaign %d %q: %v\n", row.CampaignID, row.Campaign, err)
+			failed++
+			continue
+		}
+		synced++
+	}
+
+	if getFormat() == output.FormatTable {
+		fmt.Printf("Synced %d campaign(s).\n", synced)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d campaign(s) failed to sync", failed, synced+failed)
+	}
+	return nil
+}
+
+// syncCampaignNegatives applies one campaign's additions and removals.
+func syncCampaignNegatives(svc *services.KeywordService, row *nkSyncRow) error {
+	for _, batch := range services.Chunk(row.add, services.KeywordBulkLimit) {
+		if _, err := svc.CreateCampaignNegativeKeywords(row.CampaignID, batch); err != nil {
+			return fmt.Errorf("adding negative keywords: %w", err)
+		}
+	}
+	for _, batch := range services.Chunk(row.del, services.KeywordBulkLimit) {
+		ids := make([]int64, len(batch))
+		for i, nk := range batch {
+			ids[i] = nk.ID
+		}
+		if err := svc.DeleteCampaignNegativeKeywords(row.CampaignID, ids); err != nil {
+			return fmt.Errorf("removing negative keywords: %w", err)
+		}
+	}
+	return nil
+}
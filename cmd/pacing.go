@@ -0,0 +1,303 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/trebuhs/asa-cli/internal/api"
+	"github.com/trebuhs/asa-cli/internal/models"
+	"github.com/trebuhs/asa-cli/internal/output"
+	"github.com/trebuhs/asa-cli/internal/pacing"
+	"github.com/trebuhs/asa-cli/internal/services"
+)
+
+var pacingCmd = &cobra.Command{
+	Use:   "pacing",
+	Short: "Budget pacing and spend forecast for enabled campaigns",
+	Long: `Compare each enabled campaign's spend with its budgets:
+
+  SPENT %       spend since the campaign started, against the total budget
+  RUN RATE      average daily spend over the last --days complete days, or
+                the days since the campaign started if fewer
+  EXHAUSTS      the day the total budget runs out at the run rate, if this month
+  CAPPED        days in that window that spent the daily budget
+  PROJECTED     this month's spend if the run rate holds, against the plan
+                (daily budget x days in the month it runs)
+  BO REMAINING  what is left on the budget order the campaign draws from
+
+Campaigns whose projected month is more than --tolerance off the plan are
+flagged OVERSPEND or UNDERSPEND; those that run out of total budget this month
+are flagged EXHAUSTS.`,
+	RunE: runPacing,
+}
+
+var (
+	pacingCampaigns []string
+	pacingDays      int
+	pacingTolerance float64
+)
+
+func init() {
+	pacingCmd.Flags().StringSliceVar(&pacingCampaigns, "campaigns", nil, `Campaign filter, same syntax as --filter (e.g. "name~Brand") (default: all campaigns)`)
+	pacingCmd.Flags().IntVar(&pacingDays, "days", 7, "Days the run rate is averaged over (at most 90)")
+	pacingCmd.Flags().Float64Var(&pacingTolerance, "tolerance", 0.2, "Fraction the projected month may differ from the plan before it is flagged")
+
+	rootCmd.AddCommand(pacingCmd)
+}
+
+// maxPacingDays caps the run-rate window, which sets how much daily report
+// data is requested.
+const maxPacingDays = 90
+
+type pacingRow struct {
+	CampaignID     int64   `json:"campaignId"`
+	Campaign       string  `json:"campaign"`
+	Currency       string  `json:"currency"`
+	Budget         float64 `json:"budget,omitempty"`
+	DailyBudget    float64 `json:"dailyBudget"`
+	Spent          float64 `json:"spent"`
+	SpentPct       float64 `json:"spentPct,omitempty"`
+	RunRate        float64 `json:"runRate"`
+	Exhaustion     string  `json:"exhaustion,omitempty"`
+	CappedDays     int     `json:"cappedDays"`
+	MonthToDate    float64 `json:"monthToDate"`
+	MonthProjected float64 `json:"monthProjected"`
+	MonthPlan      float64 `json:"monthPlan"`
+	Flag           string  `json:"flag"`
+	// BudgetOrderID and BudgetOrderRemaining are set for campaigns that draw
+	// from a budget order.
+	BudgetOrderID        int64    `json:"budgetOrderId,omitempty"`
+	BudgetOrderRemaining *float64 `json:"budgetOrderRemaining,omitempty"`
+}
+
+var pacingColumns = []output.Column{
+	{Header: "ID", Field: "CampaignID", Width: 12},
+	{Header: "CAMPAIGN", Field: "Campaign", Width: 25},
+	{Header: "CUR", Field: "Currency", Width: 4},
+	{Header: "BUDGET", Field: "Budget", Width: 10},
+	{Header: "SPENT", Field: "Spent", Width: 10},
+	{Header: "SPENT %", Field: "SpentPct", Width: 7},
+	{Header: "DAILY", Field: "DailyBudget", Width: 8},
+	{Header: "RUN RATE", Field: "RunRate", Width: 8},
+	{Header: "CAPPED", Field: "CappedDays", Width: 6},
+	{Header: "EXHAUSTS", Field: "Exhaustion", Width: 10},
+	{Header: "MONTH TO DATE", Field: "MonthToDate", Width: 10},
+	{Header: "PROJECTED", Field: "MonthProjected", Width: 10},
+	{Header: "PLAN", Field: "MonthPlan", Width: 10},
+	{Header: "FLAG", Field: "Flag", Width: 10},
+	{Header: "BO REMAINING", Field: "BudgetOrderRemaining", Width: 12},
+}
+
+func runPacing(cmd *cobra.Command, args []string) error {
+	if pacingDays < 1 || pacingDays > maxPacingDays {
+		return fmt.Errorf("--days must be between 1 and %d", maxPacingDays)
+	}
+
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	selector := models.NewSelector(1000, 0)
+	selector.Conditions = parseFilters(pacingCampaigns)
+	found, err := services.NewCampaignService(client).FindAll(selector)
+	if err != nil {
+		return fmt.Errorf("finding campaigns: %w", err)
+	}
+
+	today := time.Now()
+	var campaigns []models.Campaign
+	var ids []string
+	earliest := today.Format("2006-01-02")
+	for _, c := range found {
+		if c.Deleted || c.Status != "ENABLED" {
+			continue
+		}
+		campaigns = append(campaigns, c)
+		ids = append(ids, strconv.FormatInt(c.ID, 10))
+		if len(c.StartTime) >= 10 && c.StartTime[:10] < earliest {
+			earliest = c.StartTime[:10]
+		}
+	}
+	if len(campaigns) == 0 {
+		if getFormat() == output.FormatTable {
+			fmt.Println("No enabled campaigns.")
+		}
+		return nil
+	}
+
+	// Daily spend covers the month so far and the run-rate window.
+	from := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if w := today.AddDate(0, 0, -pacingDays); w.Before(from) {
+		from = w
+	}
+	reports := services.NewReportingService(client)
+	daily, err := reports.GetCampaignReport(pacingReportRequest(ids, from.Format("2006-01-02"), today.Format("2006-01-02"), "DAILY"))
+	if err != nil {
+		return fmt.Errorf("getting daily spend: %w", err)
+	}
+	lifetime, err := reports.GetCampaignReport(pacingReportRequest(ids, earliest, today.Format("2006-01-02"), ""))
+	if err != nil {
+		return fmt.Errorf("getting spend to date: %w", err)
+	}
+
+	dailySpend := map[int64]map[string]float64{}
+	for _, row := range daily.Row {
+		id := reportCampaignID(row)
+		if dailySpend[id] == nil {
+			dailySpend[id] = map[string]float64{}
+		}
+		for _, g := range row.Granularity {
+			if g.Metrics != nil && len(g.Date) >= 10 {
+				dailySpend[id][g.Date[:10]] += g.Metrics.LocalSpend.Float()
+			}
+		}
+	}
+	spent := map[int64]float64{}
+	for _, row := range lifetime.Row {
+		if row.Total != nil {
+			spent[reportCampaignID(row)] = row.Total.LocalSpend.Float()
+		}
+	}
+
+	orders, err := campaignBudgetOrders(client, campaigns, len(pacingCampaigns) > 0)
+	if err != nil {
+		return err
+	}
+
+	rows := make([]pacingRow, 0, len(campaigns))
+	for _, c := range campaigns {
+		in := pacing.Input{
+			Budget:      c.BudgetAmount.Float(),
+			DailyBudget: c.DailyBudgetAmount.Float(),
+			Spent:       spent[c.ID],
+			Daily:       dailySpend[c.ID],
+			Today:       today,
+			Start:       campaignStart(c),
+			Window:      pacingDays,
+			Tolerance:   pacingTolerance,
+		}
+		p := pacing.Compute(in)
+		row := pacingRow{
+			CampaignID:     c.ID,
+			Campaign:       c.Name,
+			Budget:         in.Budget,
+			DailyBudget:    in.DailyBudget,
+			Spent:          round2(in.Spent),
+			SpentPct:       round2(p.SpentPct),
+			RunRate:        round2(p.RunRate),
+			Exhaustion:     p.Exhaustion,
+			CappedDays:     p.CappedDays,
+			MonthToDate:    round2(p.MonthToDate),
+			MonthProjected: round2(p.MonthProjected),
+			MonthPlan:      round2(p.MonthPlan),
+			Flag:           p.Flag,
+		}
+		if c.DailyBudgetAmount != nil {
+			row.Currency = c.DailyBudgetAmount.Currency
+		}
+		if bo := orders[c.ID]; bo != nil {
+			row.BudgetOrderID = bo.ID
+			row.BudgetOrderRemaining = &bo.Remaining
+		}
+		rows = append(rows, row)
+	}
+
+	output.Print(getFormat(), rows, pacingColumns)
+	return nil
+}
+
+// campaignBudgetOrders returns the balance of the budget order each campaign
+// draws from: its active one, or else the first. Balances count the spend of
+// every campaign on the order, so all campaigns are fetched when the list
+// was filtered.
+func campaignBudgetOrders(client *api.Client, campaigns []models.Campaign, filtered bool) (map[int64]*budgetOrderBalance, error) {
+	var ids []int64
+	seen := map[int64]bool{}
+	for _, c := range campaigns {
+		for _, id := range c.BudgetOrders {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	all := campaigns
+	if filtered {
+		var err error
+		if all, err = services.NewCampaignService(client).FindAll(models.NewSelector(1000, 0)); err != nil {
+			return nil, fmt.Errorf("finding campaigns: %w", err)
+		}
+	}
+
+	svc := services.NewBudgetOrderService(client)
+	balances := map[int64]*budgetOrderBalance{}
+	for _, id := range ids {
+		order, err := svc.Get(id)
+		if err != nil {
+			return nil, fmt.Errorf("getting budget order %d: %w", id, err)
+		}
+		if balances[id], err = budgetOrderBalanceOf(client, *order, all); err != nil {
+			return nil, err
+		}
+	}
+
+	byCampaign := map[int64]*budgetOrderBalance{}
+	for _, c := range campaigns {
+		for _, id := range c.BudgetOrders {
+			if byCampaign[c.ID] == nil || balances[id].Status == "ACTIVE" && byCampaign[c.ID].Status != "ACTIVE" {
+				byCampaign[c.ID] = balances[id]
+			}
+		}
+	}
+	return byCampaign, nil
+}
+
+func pacingReportRequest(campaignIDs []string, start, end, granularity string) *models.ReportRequest {
+	return &models.ReportRequest{
+		StartTime:   start,
+		EndTime:     end,
+		Granularity: granularity,
+		TimeZone:    "ORTZ",
+		Selector: &models.Selector{
+			Conditions: []models.Condition{{Field: "campaignId", Operator: "IN", Values: campaignIDs}},
+			Pagination: models.SelectorPagination{Offset: 0, Limit: 1000},
+		},
+		ReturnRecordsWithNoMetrics: true,
+	}
+}
+
+// campaignStart returns the day a campaign started, or zero if unknown.
+func campaignStart(c models.Campaign) time.Time {
+	if len(c.StartTime) < 10 {
+		return time.Time{}
+	}
+	t, err := time.Parse("2006-01-02", c.StartTime[:10])
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// reportCampaignID returns the campaign ID in a report row's metadata.
+func reportCampaignID(row models.ReportRow) int64 {
+	switch v := row.Metadata["campaignId"].(type) {
+	case float64:
+		return int64(v)
+	case string:
+		id, _ := strconv.ParseInt(v, 10, 64)
+		return id
+	}
+	return 0
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/trebuhs/asa-cli/internal/api"
+	"github.com/trebuhs/asa-cli/internal/manifest"
+	"github.com/trebuhs/asa-cli/internal/models"
+	"github.com/trebuhs/asa-cli/internal/output"
+	"github.com/trebuhs/asa-cli/internal/state"
+)
+
+var planCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Show changes needed to match a YAML account manifest",
+	Long: `Diff campaigns, ad groups, keywords and negative keywords declared in YAML
+manifests against live state, without changing anything.
+
+Only campaigns listed in a manifest are managed. Within a managed campaign,
+entries missing from a listed ad_groups, keywords or negative_keywords
+collection are deleted; collections left out of the manifest are kept.`,
+	RunE: runPlan,
+}
+
+var applyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Apply a YAML account manifest",
+	Long:  "Compute the same plan as 'asa-cli plan' and execute it in dependency order.",
+	RunE:  runApply,
+}
+
+var manifestFiles []string
+
+func init() {
+	for _, cmd := range []*cobra.Command{planCmd, applyCmd} {
+		cmd.Flags().StringSliceVarP(&manifestFiles, "file", "f", nil, "Manifest file or directory (repeatable) (required)")
+		cmd.MarkFlagRequired("file")
+	}
+
+	rootCmd.AddCommand(planCmd, applyCmd)
+}
+
+func runPlan(cmd *cobra.Command, args []string) error {
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	plan, _, err := buildManifestPlan(client)
+	if err != nil {
+		return err
+	}
+
+	printPlan(plan)
+	return nil
+}
+
+func runApply(cmd *cobra.Command, args []string) error {
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	plan, m, err := buildManifestPlan(client)
+	if err != nil {
+		return err
+	}
+
+	printPlan(plan)
+	if len(plan.Changes) == 0 {
+		return nil
+	}
+
+	if err := checkManifestLimits(m); err != nil {
+		return err
+	}
+	if err := checkChangeCount(client, len(plan.Changes)); err != nil {
+		return err
+	}
+
+	if deletes := plan.Count(manifest.ActionDelete); deletes > 0 {
+		var details []string
+		for _, c := range plan.Changes {
+			if c.Action == manifest.ActionDelete {
+				details = append(details, fmt.Sprintf("%s %s", c.Kind, c.Target()))
+			}
+		}
+		if err := confirmDestructive(fmt.Sprintf("apply a plan that deletes %d entities", deletes), details); err != nil {
+			return err
+		}
+	}
+
+	currency, err := resolveOrgCurrency(client)
+	if err != nil {
+		return err
+	}
+
+	applier := manifest.NewApplier(client, currency)
+	if getFormat() == output.FormatTable {
+		fmt.Println()
+		applier.OnChange = func(c manifest.Change) {
+			fmt.Printf("%s %s %s\n", actionSymbol(c.Action), c.Kind, c.Target())
+		}
+	}
+	if err := applier.Apply(plan); err != nil {
+		return fmt.Errorf("applying manifest: %w", err)
+	}
+
+	if getFormat() == output.FormatTable {
+		fmt.Printf("\nApply complete: %d created, %d updated, %d paused, %d deleted.\n",
+			plan.Count(manifest.ActionCreate), plan.Count(manifest.ActionUpdate),
+			plan.Count(manifest.ActionPause), plan.Count(manifest.ActionDelete))
+	}
+	return nil
+}
+
+// buildManifestPlan loads the manifests and diffs them against the campaigns they name.
+func buildManifestPlan(client *api.Client) (*manifest.Plan, *manifest.Manifest, error) {
+	m, err := manifest.Load(manifestFiles)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	managed := map[string]bool{}
+	for _, c := range m.Campaigns {
+		managed[c.Name] = true
+	}
+
+	live, err := state.Fetch(client, func(c models.Campaign) bool { return managed[c.Name] })
+	if err != nil {
+		return nil, nil, err
+	}
+
+	plan, err := manifest.BuildPlan(m, live)
+	if err != nil {
+		return nil, nil, err
+	}
+	return plan, m, nil
+}
+
+// checkManifestLimits runs the policy limits over every amount in a manifest,
+// so an invalid amount fails the apply before anything is sent.
+func checkManifestLimits(m *manifest.Manifest) error {
+	l := limits()
+	for _, c := range m.Campaigns {
+		if c.Budget != "" {
+			if err := l.Budget(c.Budget); err != nil {
+				return fmt.Errorf("campaign %q: %w", c.Name, err)
+			}
+		}
+		if c.DailyBudget != "" {
+			if err := l.DailyBudget(c.DailyBudget); err != nil {
+				return fmt.Errorf("campaign %q: %w", c.Name, err)
+			}
+		}
+		for _, ag := range c.AdGroups {
+			if ag.DefaultBid != "" {
+				if err := l.Bid(ag.DefaultBid); err != nil {
+					return fmt.Errorf("ad group %q: %w", ag.Name, err)
+				}
+				if err := l.WithinDailyBudget("default bid", ag.DefaultBid, c.DailyBudget); err != nil {
+					return fmt.Errorf("ad group %q: %w", ag.Name, err)
+				}
+			}
+			if ag.CpaGoal != "" {
+				if err := l.CpaGoal(ag.CpaGoal); err != nil {
+					return fmt.Errorf("ad group %q: %w", ag.Name, err)
+				}
+				if err := l.WithinDailyBudget("CPA goal", ag.CpaGoal, c.DailyBudget); err != nil {
+					return fmt.Errorf("ad group %q: %w", ag.Name, err)
+				}
+			}
+			for _, kw := range ag.Keywords {
+				if kw.Bid != "" {
+					if err := l.Bid(kw.Bid); err != nil {
+						return fmt.Errorf("keyword %q: %w", kw.Text, err)
+					}
+					if err := l.WithinDailyBudget("bid", kw.Bid, c.DailyBudget); err != nil {
+						return fmt.Errorf("keyword %q: %w", kw.Text, err)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func printPlan(plan *manifest.Plan) {
+	if getFormat() == output.FormatJSON {
+		output.Print(output.FormatJSON, plan, nil)
+		return
+	}
+
+	if len(plan.Changes) == 0 {
+		fmt.Println("No changes. Live state matches the manifest.")
+		return
+	}
+
+	for _, c := range plan.Changes {
+		fmt.Printf("%s %s %s\n", actionSymbol(c.Action), c.Kind, c.Target())
+		for _, f := range c.Fields {
+			fmt.Printf("    %s: %q -> %q\n", f.Field, f.Old, f.New)
+		}
+	}
+
+	fmt.Printf("\nPlan: %d to create, %d to update, %d to pause, %d to delete.\n",
+		plan.Count(manifest.ActionCreate), plan.Count(manifest.ActionUpdate),
+		plan.Count(manifest.ActionPause), plan.Count(manifest.ActionDelete))
+}
+
+func actionSymbol(action manifest.Action) string {
+	switch action {
+	case manifest.ActionCreate:
+		return color.GreenString("+")
+	case manifest.ActionDelete:
+		return color.RedString("-")
+	case manifest.ActionPause:
+		return color.YellowString("‖")
+	default:
+		return color.YellowString("~")
+	}
+}
@@ -13,20 +13,29 @@ import (
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/trebuhs/asa-cli/internal/api"
+	"github.com/trebuhs/asa-cli/internal/audit"
 	"github.com/trebuhs/asa-cli/internal/auth"
 	"github.com/trebuhs/asa-cli/internal/config"
 	"github.com/trebuhs/asa-cli/internal/models"
 	"github.com/trebuhs/asa-cli/internal/output"
+	"github.com/trebuhs/asa-cli/internal/policy"
 	"github.com/trebuhs/asa-cli/internal/services"
 )
 
 var (
-	outputFormat string
-	profileName  string
-	verbose      bool
-	noColor      bool
-	globalOrgID  string
-	forceFlag    bool
+	outputFormat   string
+	profileName    string
+	verbose        bool
+	noColor        bool
+	globalOrgID    string
+	forceFlag      bool
+	dryRun         bool
+	yesFlag        bool
+	overrideReason string
+
+	// clientOrgID is the org newAPIClient sends requests to, after
+	// auto-detection.
+	clientOrgID string
 )
 
 var rootCmd = &cobra.Command{
@@ -49,11 +58,16 @@ func init() {
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Verbose output")
 	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable color output")
 	rootCmd.PersistentFlags().StringVar(&globalOrgID, "org-id", "", "Organization ID (overrides config)")
-	rootCmd.PersistentFlags().BoolVar(&forceFlag, "force", false, "Skip budget/bid safety checks")
+	rootCmd.PersistentFlags().BoolVar(&forceFlag, "force", false, "Skip max_daily_budget/max_bid checks")
+	rootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Print mutating requests instead of sending them")
+	rootCmd.PersistentFlags().BoolVarP(&yesFlag, "yes", "y", false, "Skip confirmation prompts for destructive commands")
+	rootCmd.PersistentFlags().StringVar(&overrideReason, "override-reason", "", "Proceed despite guardrail violations, recording this reason in the audit log")
 }
 
 func Execute() error {
-	if err := rootCmd.Execute(); err != nil {
+	err := rootCmd.Execute()
+	printPlannedRequests()
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		return err
 	}
@@ -97,6 +111,7 @@ func newAPIClient() (*api.Client, error) {
 		}
 		orgID = resolved
 	}
+	clientOrgID = orgID
 
 	transport := &auth.Transport{
 		Token:   tokenProvider,
@@ -111,6 +126,16 @@ func newAPIClient() (*api.Client, error) {
 
 	client := api.NewClient(httpClient)
 	client.Verbose = verbose
+	client.DryRun = dryRun
+	client.ReadOnly = cfg.ReadOnly
+	plannedClients = append(plannedClients, client)
+	client.Audit = audit.NewLog(profileName, orgID)
+	client.Audit.OverrideReason = overrideReason
+	guardrails := policy.NewGuardrails(cfg, client)
+	guardrails.OverrideReason = overrideReason
+	guardrails.Force = forceFlag
+	guardrails.OrgID = orgID
+	client.Policy = guardrails
 	return client, nil
 }
 
@@ -139,6 +164,9 @@ func newAPIClientNoOrg() (*api.Client, error) {
 
 	client := api.NewClient(httpClient)
 	client.Verbose = verbose
+	client.DryRun = dryRun
+	client.ReadOnly = cfg.ReadOnly
+	plannedClients = append(plannedClients, client)
 	return client, nil
 }
 
@@ -244,36 +272,25 @@ func parseSorts(sorts []string) []models.OrderByItem {
 	return items
 }
 
-// checkBudgetLimit validates a daily budget against the configured max.
-func checkBudgetLimit(amount string) error {
-	if forceFlag {
-		return nil
-	}
+// limits returns the amount checks for the current profile. Commands that
+// send several requests use it to validate everything up front; every
+// request is checked again by the client's policy before it is sent.
+func limits() policy.Limits {
 	cfg, err := config.Load()
 	if err != nil {
-		return nil // don't block on config errors
-	}
-	val, err := strconv.ParseFloat(amount, 64)
-	if err != nil {
-		return nil
+		cfg = &config.Config{} // don't block on config errors
 	}
-	return cfg.CheckDailyBudget(val)
+	return policy.Limits{Config: cfg, Force: forceFlag}
 }
 
-// checkBidLimit validates a bid amount against the configured max.
-func checkBidLimit(amount string) error {
-	if forceFlag {
-		return nil
+// checkChangeCount checks up front that a command may change n entities, both
+// the user's role and max_entities_per_command, so it is refused before
+// sending anything.
+func checkChangeCount(client *api.Client, n int) error {
+	if g, ok := client.Policy.(*policy.Guardrails); ok {
+		return g.Expect(n)
 	}
-	cfg, err := config.Load()
-	if err != nil {
-		return nil
-	}
-	val, err := strconv.ParseFloat(amount, 64)
-	if err != nil {
-		return nil
-	}
-	return cfg.CheckBid(val)
+	return nil
 }
 
 // resolveOrgCurrency fetches /acls and returns the currency for the given org ID.
@@ -285,13 +302,7 @@ func resolveOrgCurrency(client *api.Client) (string, error) {
 	}
 
 	// Match against the org ID set on the client
-	orgID := globalOrgID
-	if orgID == "" {
-		cfg, _ := config.Load()
-		if cfg != nil {
-			orgID = cfg.OrgID
-		}
-	}
+	orgID := configuredOrgID()
 
 	for _, acl := range acls {
 		if orgID == "" || strconv.FormatInt(acl.OrgID, 10) == orgID {
@@ -305,6 +316,28 @@ func resolveOrgCurrency(client *api.Client) (string, error) {
 	return "", fmt.Errorf("could not resolve org currency: no organizations found")
 }
 
+// requestOrgID returns the org the API client sends requests to. It is only
+// known after newAPIClient.
+func requestOrgID() (int64, error) {
+	if clientOrgID == "" {
+		return 0, fmt.Errorf("could not determine the org ID; pass --org-id")
+	}
+	return strconv.ParseInt(clientOrgID, 10, 64)
+}
+
+// configuredOrgID returns the org ID from the --org-id flag or config, or "" when
+// it is auto-detected.
+func configuredOrgID() string {
+	if globalOrgID != "" {
+		return globalOrgID
+	}
+	cfg, _ := config.Load()
+	if cfg != nil {
+		return cfg.OrgID
+	}
+	return ""
+}
+
 // exitWithError prints an error and exits with the given code.
 func exitWithError(msg string, code int) {
 	fmt.Fprintf(os.Stderr, "Error: %s\n", msg)
@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/trebuhs/asa-cli/internal/output"
+	"github.com/trebuhs/asa-cli/internal/state"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the full account structure to a snapshot file",
+	Long: `Dump every campaign, ad group (with targeting), targeting keyword and
+campaign- and ad-group-level negative keyword into one versioned JSON document.`,
+	RunE: runExport,
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import <snapshot.json>",
+	Short: "Recreate a snapshot's structure in the current org",
+	Long: `Create every campaign, ad group, keyword and negative keyword from a snapshot
+in the org selected by --org-id/--profile. New IDs are printed next to the
+IDs they replace.
+
+Importing into the org the snapshot was exported from duplicates every
+campaign, so it is refused unless --allow-same-org is given. LOC invoice
+details are only copied with --copy-invoice-details. Amounts are converted
+with --fx-rate, which is required when the target org uses another currency.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runImport,
+}
+
+var (
+	snapOut          string
+	snapPaused       bool
+	snapAllowSameOrg bool
+	snapCopyInvoice  bool
+	snapFXRate       float64
+)
+
+func init() {
+	exportCmd.Flags().StringVar(&snapOut, "out", "", "Output file (default: stdout)")
+
+	importCmd.Flags().BoolVar(&snapPaused, "paused", false, "Create all campaigns as PAUSED")
+	importCmd.Flags().BoolVar(&snapAllowSameOrg, "allow-same-org", false, "Import into the org the snapshot was exported from")
+	importCmd.Flags().BoolVar(&snapCopyInvoice, "copy-invoice-details", false, "Copy the campaigns' LOC invoice details")
+	importCmd.Flags().Float64Var(&snapFXRate, "fx-rate", 0, "Rate converting the snapshot's amounts into the target org's currency (e.g. 149.5 for USD to JPY)")
+
+	rootCmd.AddCommand(exportCmd, importCmd)
+}
+
+var idMappingColumns = []output.Column{
+	{Header: "KIND", Field: "Kind", Width: 10},
+	{Header: "NAME", Field: "Name", Width: 30},
+	{Header: "OLD ID", Field: "OldID", Width: 12},
+	{Header: "NEW ID", Field: "NewID", Width: 12},
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	account, err := state.Fetch(client, nil)
+	if err != nil {
+		return fmt.Errorf("exporting account: %w", err)
+	}
+	snap := state.NewSnapshot(clientOrgID, account)
+
+	if snapOut == "" {
+		output.Print(output.FormatJSON, snap, nil)
+		return nil
+	}
+
+	if err := state.WriteSnapshot(snapOut, snap); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Exported %d campaign(s) to %s.\n", len(snap.Campaigns), snapOut)
+	return nil
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	snap, err := state.ReadSnapshot(args[0])
+	if err != nil {
+		return err
+	}
+	if snapFXRate < 0 {
+		return fmt.Errorf("--fx-rate must be positive, got %v", snapFXRate)
+	}
+
+	if err := checkAccountLimits(&snap.Account); err != nil {
+		return err
+	}
+
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	orgID, err := requestOrgID()
+	if err != nil {
+		return err
+	}
+	target := strconv.FormatInt(orgID, 10)
+	if snap.OrgID == target && !snapAllowSameOrg {
+		return fmt.Errorf("snapshot was exported from org %s, the org it would be imported into; this duplicates every campaign (pass --allow-same-org to do it anyway)", target)
+	}
+
+	currency, err := resolveOrgCurrency(client)
+	if err != nil {
+		return err
+	}
+
+	if source := snap.Currency(); source != "" && source != currency && snapFXRate == 0 {
+		return fmt.Errorf("snapshot amounts are in %s but org %s uses %s; pass --fx-rate to convert them", source, target, currency)
+	}
+
+	count := 0
+	for i := range snap.Campaigns {
+		count += snap.Campaigns[i].EntityCount()
+	}
+	if err := checkChangeCount(client, count); err != nil {
+		return err
+	}
+	if err := confirmDestructive(fmt.Sprintf("create %d entities in org %s", count, target), snapshotSummary(&snap.Account)); err != nil {
+		return err
+	}
+
+	importer := state.NewImporter(client, currency)
+	importer.Rate = snapFXRate
+	importer.CopyInvoiceDetails = snapCopyInvoice
+	if snapPaused {
+		importer.Status = "PAUSED"
+	}
+	if err := importer.ImportAccount(&snap.Account); err != nil {
+		return fmt.Errorf("importing snapshot: %w", err)
+	}
+
+	output.Print(getFormat(), importer.Mappings, idMappingColumns)
+	return nil
+}
+
+// snapshotSummary lists each campaign of an import with what it contains.
+func snapshotSummary(account *state.Account) []string {
+	var lines []string
+	for _, c := range account.Campaigns {
+		keywords := 0
+		for _, ag := range c.AdGroups {
+			keywords += len(ag.Keywords)
+		}
+		lines = append(lines, fmt.Sprintf("campaign %q (%s): %d ad group(s), %d keyword(s)", c.Campaign.Name, strings.Join(c.Campaign.CountriesOrRegions, ","), len(c.AdGroups), keywords))
+	}
+	return lines
+}
+
+// checkAccountLimits runs the policy limits over every amount in an account
+// tree, so an invalid amount fails the command before anything is sent.
+func checkAccountLimits(account *state.Account) error {
+	l := limits()
+	for _, c := range account.Campaigns {
+		if err := l.Campaign(&c.Campaign); err != nil {
+			return fmt.Errorf("campaign %q: %w", c.Campaign.Name, err)
+		}
+		daily := c.Campaign.DailyBudgetAmount
+		for _, ag := range c.AdGroups {
+			if err := l.AdGroup(&ag.AdGroup, daily); err != nil {
+				return fmt.Errorf("ad group %q: %w", ag.AdGroup.Name, err)
+			}
+			for _, kw := range ag.Keywords {
+				if kw.BidAmount != nil {
+					if err := l.Keyword(kw.BidAmount, daily); err != nil {
+						return fmt.Errorf("keyword %q: %w", kw.Text, err)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
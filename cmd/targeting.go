@@ -0,0 +1,269 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/trebuhs/asa-cli/internal/api"
+	"github.com/trebuhs/asa-cli/internal/daypart"
+	"github.com/trebuhs/asa-cli/internal/models"
+	"github.com/trebuhs/asa-cli/internal/output"
+	"github.com/trebuhs/asa-cli/internal/services"
+)
+
+var adgroupsTargetingCmd = &cobra.Command{
+	Use:   "targeting",
+	Short: "View ad group targeting",
+}
+
+var adgroupsTargetingShowCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "Show the targeting dimensions of an ad group",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runAdGroupsTargetingShow,
+}
+
+var (
+	agDevices        []string
+	agAge            string
+	agGender         string
+	agAdminAreas     []string
+	agLocalities     []string
+	agAppDownloaders string
+)
+
+var targetingFlags = []string{"device", "age", "gender", "admin-area", "locality", "app-downloaders"}
+
+func init() {
+	for _, cmd := range []*cobra.Command{adgroupsCreateCmd, adgroupsUpdateCmd} {
+		cmd.Flags().StringSliceVar(&agDevices, "device", nil, "Device classes (IPHONE,IPAD)")
+		cmd.Flags().StringVar(&agAge, "age", "", `Age range (e.g. 18-34, 65+, or "all")`)
+		cmd.Flags().StringVar(&agGender, "gender", "", `Gender (M, F or "all")`)
+		cmd.Flags().StringSliceVar(&agAdminAreas, "admin-area", nil, `Admin area IDs from 'geo search' (e.g. US|CA), or "all"`)
+		cmd.Flags().StringSliceVar(&agLocalities, "locality", nil, `Locality IDs from 'geo search' (e.g. US|CA|Cupertino), or "all"`)
+		cmd.Flags().StringVar(&agAppDownloaders, "app-downloaders", "", `Target new or returning users of the campaign app (new|returning|all)`)
+	}
+
+	adgroupsTargetingShowCmd.Flags().Int64Var(&agCampaignID, "campaign-id", 0, "Campaign ID (required)")
+	adgroupsTargetingShowCmd.MarkFlagRequired("campaign-id")
+
+	adgroupsTargetingCmd.AddCommand(adgroupsTargetingShowCmd)
+	adgroupsCmd.AddCommand(adgroupsTargetingCmd)
+}
+
+// targetingChanged reports whether any targeting flag was passed.
+func targetingChanged(cmd *cobra.Command) bool {
+	for _, name := range targetingFlags {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyTargeting sets the dimensions given on the command line on td and
+// leaves the others as they are. "all" removes a restriction.
+func applyTargeting(cmd *cobra.Command, client *api.Client, campaignID int64, td *models.TargetingDimensions) error {
+	flags := cmd.Flags()
+
+	if flags.Changed("device") {
+		devices, err := parseDevices(agDevices)
+		if err != nil {
+			return err
+		}
+		td.DeviceClass = devices
+	}
+	if flags.Changed("age") {
+		age, err := parseAge(agAge)
+		if err != nil {
+			return err
+		}
+		td.Age = age
+	}
+	if flags.Changed("gender") {
+		gender, err := parseGender(agGender)
+		if err != nil {
+			return err
+		}
+		td.Gender = gender
+	}
+	if flags.Changed("admin-area") {
+		td.AdminArea = geoCriteria(agAdminAreas)
+	}
+	if flags.Changed("locality") {
+		td.Locality = geoCriteria(agLocalities)
+	}
+	if flags.Changed("app-downloaders") {
+		mode := strings.ToLower(agAppDownloaders)
+		switch mode {
+		case "all":
+			td.AppDownloaders = nil
+		case "new", "returning":
+			campaign, err := services.NewCampaignService(client).Get(campaignID)
+			if err != nil {
+				return fmt.Errorf("getting campaign: %w", err)
+			}
+			app := []string{strconv.FormatInt(campaign.AdamID, 10)}
+			if mode == "new" {
+				td.AppDownloaders = &models.StringCriteria{Excluded: app}
+			} else {
+				td.AppDownloaders = &models.StringCriteria{Included: app}
+			}
+		default:
+			return fmt.Errorf("invalid --app-downloaders %q (expected new, returning or all)", agAppDownloaders)
+		}
+	}
+	return nil
+}
+
+func parseDevices(values []string) (*models.StringCriteria, error) {
+	if len(values) == 1 && strings.EqualFold(values[0], "all") {
+		return nil, nil
+	}
+	criteria := &models.StringCriteria{}
+	for _, v := range values {
+		device := strings.ToUpper(strings.TrimSpace(v))
+		if device != "IPHONE" && device != "IPAD" {
+			return nil, fmt.Errorf("invalid device %q (expected IPHONE or IPAD)", v)
+		}
+		criteria.Included = append(criteria.Included, device)
+	}
+	if len(criteria.Included) == 0 {
+		return nil, fmt.Errorf("--device needs at least one device class")
+	}
+	return criteria, nil
+}
+
+// parseAge parses "18-34", "65+" or "all". Search Ads targets ages 18 to 65.
+func parseAge(s string) (*models.AgeCriteria, error) {
+	if strings.EqualFold(s, "all") {
+		return nil, nil
+	}
+	var r models.AgeRange
+	var err error
+	if strings.HasSuffix(s, "+") {
+		r.MinAge, err = strconv.Atoi(strings.TrimSuffix(s, "+"))
+	} else if lo, hi, ok := strings.Cut(s, "-"); ok {
+		r.MinAge, err = strconv.Atoi(lo)
+		if err == nil {
+			r.MaxAge, err = strconv.Atoi(hi)
+		}
+	} else {
+		err = fmt.Errorf("no range")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid --age %q (expected e.g. 18-34 or 65+)", s)
+	}
+	if r.MinAge < 18 || r.MinAge > 65 || (r.MaxAge != 0 && (r.MaxAge < r.MinAge || r.MaxAge > 65)) {
+		return nil, fmt.Errorf("invalid --age %q: ages must be between 18 and 65", s)
+	}
+	return &models.AgeCriteria{Included: []models.AgeRange{r}}, nil
+}
+
+func parseGender(s string) (*models.StringCriteria, error) {
+	switch strings.ToUpper(s) {
+	case "ALL":
+		return nil, nil
+	case "M", "MALE":
+		return &models.StringCriteria{Included: []string{"M"}}, nil
+	case "F", "FEMALE":
+		return &models.StringCriteria{Included: []string{"F"}}, nil
+	}
+	return nil, fmt.Errorf("invalid --gender %q (expected M, F or all)", s)
+}
+
+func geoCriteria(ids []string) *models.StringCriteria {
+	if len(ids) == 0 || (len(ids) == 1 && strings.EqualFold(ids[0], "all")) {
+		return nil
+	}
+	return &models.StringCriteria{Included: ids}
+}
+
+type targetingRow struct {
+	Dimension string `json:"dimension"`
+	Included  string `json:"included"`
+	Excluded  string `json:"excluded"`
+}
+
+func runAdGroupsTargetingShow(cmd *cobra.Command, args []string) error {
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid ad group ID: %s", args[0])
+	}
+
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	adgroup, err := services.NewAdGroupService(client).Get(agCampaignID, id)
+	if err != nil {
+		return fmt.Errorf("getting ad group: %w", err)
+	}
+
+	td := adgroup.TargetingDimensions
+	if td == nil {
+		td = &models.TargetingDimensions{}
+	}
+	if getFormat() == output.FormatJSON {
+		output.Print(getFormat(), td, nil)
+		return nil
+	}
+
+	rows := []targetingRow{
+		stringRow("device", td.DeviceClass),
+		ageRow(td.Age),
+		stringRow("gender", td.Gender),
+		stringRow("country", td.Country),
+		stringRow("admin area", td.AdminArea),
+		stringRow("locality", td.Locality),
+		stringRow("app downloaders", td.AppDownloaders),
+		dayPartRow(td.DayPart),
+	}
+	output.Print(getFormat(), rows, []output.Column{
+		{Header: "DIMENSION", Field: "Dimension", Width: 16},
+		{Header: "INCLUDED", Field: "Included", Width: 40},
+		{Header: "EXCLUDED", Field: "Excluded", Width: 30},
+	})
+	return nil
+}
+
+func stringRow(dimension string, c *models.StringCriteria) targetingRow {
+	if c == nil || (len(c.Included) == 0 && len(c.Excluded) == 0) {
+		return targetingRow{Dimension: dimension, Included: "all"}
+	}
+	return targetingRow{Dimension: dimension, Included: strings.Join(c.Included, ", "), Excluded: strings.Join(c.Excluded, ", ")}
+}
+
+func ageRow(c *models.AgeCriteria) targetingRow {
+	if c == nil || len(c.Included) == 0 {
+		return targetingRow{Dimension: "age", Included: "all"}
+	}
+	var ranges []string
+	for _, r := range c.Included {
+		if r.MaxAge == 0 {
+			ranges = append(ranges, fmt.Sprintf("%d+", r.MinAge))
+		} else {
+			ranges = append(ranges, fmt.Sprintf("%d-%d", r.MinAge, r.MaxAge))
+		}
+	}
+	return targetingRow{Dimension: "age", Included: strings.Join(ranges, ", ")}
+}
+
+func dayPartRow(c *models.DayPartCriteria) targetingRow {
+	if c == nil || c.UserTime == nil || len(c.UserTime.Included) == 0 {
+		return targetingRow{Dimension: "daypart", Included: "all"}
+	}
+	return targetingRow{Dimension: "daypart", Included: daypart.Format(c.UserTime.Included)}
+}
+
+// currentTargeting returns a copy of an ad group's targeting to modify.
+// Updates replace the whole targeting, so changes start from the current one.
+func currentTargeting(ag *models.AdGroup) models.TargetingDimensions {
+	if ag.TargetingDimensions == nil {
+		return models.TargetingDimensions{}
+	}
+	return *ag.TargetingDimensions
+}
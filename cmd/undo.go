@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/trebuhs/asa-cli/internal/audit"
+	"github.com/trebuhs/asa-cli/internal/undo"
+)
+
+var undoCmd = &cobra.Command{
+	Use:   "undo <change-id>",
+	Short: "Revert a change recorded in the audit log",
+	Long: `Restore the state from before a change listed by 'asa-cli audit'.
+
+Bid, status, budget and name updates are reverted to the values fetched just
+before the change. Deleted keywords and negative keywords are recreated (with
+new IDs), and created campaigns, ad groups and keywords are deleted. Deleted
+campaigns and ad groups cannot be restored.
+
+The undo is itself recorded in the audit log.
+
+Examples:
+  asa-cli audit --entity 123456789
+  asa-cli undo 20240601T101500-a1b2c3`,
+	Args: cobra.ExactArgs(1),
+	RunE: runUndo,
+}
+
+func init() {
+	rootCmd.AddCommand(undoCmd)
+}
+
+func runUndo(cmd *cobra.Command, args []string) error {
+	entries, err := audit.Read(audit.DefaultPath())
+	if err != nil {
+		return err
+	}
+	entry, err := audit.Find(entries, args[0])
+	if err != nil {
+		return err
+	}
+
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	if entry.OrgID != "" && entry.OrgID != client.Audit.OrgID {
+		return fmt.Errorf("change %s was made in org %s (profile %q); rerun with --org-id %s or --profile %s",
+			entry.ID, entry.OrgID, entry.Profile, entry.OrgID, entry.Profile)
+	}
+
+	plan, err := undo.Build(client, entry)
+	if err != nil {
+		return err
+	}
+
+	for _, msg := range plan.Irreversible {
+		fmt.Fprintf(os.Stderr, "Cannot undo: %s\n", msg)
+	}
+	if len(plan.Steps) == 0 {
+		return fmt.Errorf("nothing to undo for change %s", entry.ID)
+	}
+	if later := laterChanges(entries, entry); len(later) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: the same entities were changed again later (%v); undo restores the state before %s and overwrites those changes.\n", later, entry.ID)
+	}
+
+	details := make([]string, len(plan.Steps))
+	for i, s := range plan.Steps {
+		details[i] = s.Description
+	}
+	if err := confirmDestructive(fmt.Sprintf("undo change %s (%s %s)", entry.ID, entry.Method, entry.Path), details); err != nil {
+		return err
+	}
+
+	if err := plan.Run(); err != nil {
+		return fmt.Errorf("undoing change %s: %w", entry.ID, err)
+	}
+	fmt.Printf("Undid change %s.\n", entry.ID)
+	return nil
+}
+
+// laterChanges returns the IDs of changes recorded after entry that touched
+// any of the same entities.
+func laterChanges(entries []audit.Entry, entry *audit.Entry) []string {
+	touched := map[int64]bool{}
+	for _, id := range entry.EntityIDs {
+		touched[id] = true
+	}
+
+	var later []string
+	after := false
+	for _, e := range entries {
+		if e.ID == entry.ID {
+			after = true
+			continue
+		}
+		if !after || e.Error != "" {
+			continue
+		}
+		for _, id := range e.EntityIDs {
+			if touched[id] {
+				later = append(later, e.ID)
+				break
+			}
+		}
+	}
+	return later
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/trebuhs/asa-cli/internal/output"
+	"github.com/trebuhs/asa-cli/internal/policy"
 	"github.com/trebuhs/asa-cli/internal/services"
 )
 
@@ -48,7 +49,14 @@ func runWhoami(cmd *cobra.Command, args []string) error {
 	if getFormat() == output.FormatTable {
 		fmt.Printf("\nAuthenticated. %d organization(s) accessible.\n", len(acls))
 		for _, acl := range acls {
-			fmt.Printf("  %s (ID: %d) — %s\n", acl.OrgName, acl.OrgID, strings.Join(acl.RoleNames, ", "))
+			access := ""
+			switch {
+			case len(acl.RoleNames) == 0:
+				access = " (no roles, no access)"
+			case !policy.CanWrite(acl.RoleNames):
+				access = " (read-only)"
+			}
+			fmt.Printf("  %s (ID: %d) — %s%s\n", acl.OrgName, acl.OrgID, strings.Join(acl.RoleNames, ", "), access)
 		}
 	}
 
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/trebuhs/asa-cli/internal/audit"
+	"github.com/trebuhs/asa-cli/internal/models"
+)
+
+// record appends a sent mutation to the audit log. A failure to write the
+// log is reported but does not fail the request, which has already happened.
+func (c *Client) record(method, path string, reqData []byte, before json.RawMessage, overridden []string, status int, respData json.RawMessage, reqErr error) {
+	entry := audit.Entry{
+		Method:     method,
+		Path:       path,
+		Request:    reqData,
+		Before:     before,
+		Overridden: overridden,
+		Status:     status,
+		EntityIDs:  entityIDs(path, reqData, respData),
+	}
+	if reqErr != nil {
+		entry.Error = reqErr.Error()
+	}
+	if _, err := c.Audit.Record(entry); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
+
+// snapshot fetches the current state of the entities a mutation is about to
+// change, so the change can be undone later: the entity itself for a single
+// update or delete, and a find by ID for bulk updates and bulk deletes.
+// Creates have nothing to snapshot. Failures are reported and yield nil.
+func (c *Client) snapshot(method, path string, reqData []byte) json.RawMessage {
+	p := strings.SplitN(path, "?", 2)[0]
+
+	var before json.RawMessage
+	var err error
+	switch {
+	case strings.HasSuffix(p, "/delete/bulk"):
+		var ids []int64
+		if json.Unmarshal(reqData, &ids) == nil {
+			before, err = c.findByID(strings.TrimSuffix(p, "/delete/bulk"), ids)
+		}
+	case method == http.MethodPut && strings.HasSuffix(p, "/bulk"):
+		var updates []struct {
+			ID int64 `json:"id"`
+		}
+		if json.Unmarshal(reqData, &updates) == nil {
+			ids := make([]int64, len(updates))
+			for i, u := range updates {
+				ids[i] = u.ID
+			}
+			before, err = c.findByID(strings.TrimSuffix(p, "/bulk"), ids)
+		}
+	case method == http.MethodPut || method == http.MethodDelete:
+		if trailingID(p) != 0 {
+			_, err = c.Get(p, &before)
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not record state before change: %v\n", err)
+		return nil
+	}
+	return before
+}
+
+func (c *Client) findByID(collection string, ids []int64) (json.RawMessage, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	values := make([]string, len(ids))
+	for i, id := range ids {
+		values[i] = strconv.FormatInt(id, 10)
+	}
+	selector := models.NewSelector(1000, 0)
+	selector.Conditions = []models.Condition{{Field: "id", Operator: "IN", Values: values}}
+
+	found, err := PaginatedFetcher[json.RawMessage](c, collection+"/find", selector)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(found)
+}
+
+// entityIDs returns the IDs affected by a mutation: the "id" of each entity
+// in the response, else the IDs sent in a bulk delete body, else the
+// trailing ID of the request path.
+func entityIDs(path string, reqData []byte, respData json.RawMessage) []int64 {
+	var ids []int64
+
+	var one struct {
+		ID int64 `json:"id"`
+	}
+	var many []struct {
+		ID int64 `json:"id"`
+	}
+	if json.Unmarshal(respData, &one) == nil && one.ID != 0 {
+		return []int64{one.ID}
+	}
+	if json.Unmarshal(respData, &many) == nil {
+		for _, e := range many {
+			if e.ID != 0 {
+				ids = append(ids, e.ID)
+			}
+		}
+		if len(ids) > 0 {
+			return ids
+		}
+	}
+
+	p := strings.SplitN(path, "?", 2)[0]
+	if strings.HasSuffix(p, "/delete/bulk") {
+		if json.Unmarshal(reqData, &ids) == nil && len(ids) > 0 {
+			return ids
+		}
+	}
+
+	if id := trailingID(p); id != 0 {
+		return []int64{id}
+	}
+	return nil
+}
+
+// trailingID returns the numeric last segment of a path, or 0.
+func trailingID(path string) int64 {
+	id, err := strconv.ParseInt(path[strings.LastIndex(path, "/")+1:], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return id
+}
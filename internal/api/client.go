@@ -3,11 +3,14 @@ package api
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/trebuhs/asa-cli/internal/audit"
 	"github.com/trebuhs/asa-cli/internal/models"
 )
 
@@ -16,10 +19,30 @@ const (
 	defaultTimeout = 30 * time.Second
 )
 
+// ErrReadOnly is returned for any mutating request when ReadOnly is set.
+var ErrReadOnly = errors.New("profile is read-only: create, update and delete requests are blocked")
+
 type Client struct {
 	HTTP    *http.Client
 	BaseURL string
 	Verbose bool
+	// DryRun records mutating requests in Planned instead of sending them.
+	DryRun bool
+	// Planned holds the mutating requests a dry run did not send, in order.
+	Planned []PlannedRequest
+	// ReadOnly blocks all mutating requests.
+	ReadOnly bool
+	// Audit, when set, records every mutating request that is sent.
+	Audit *audit.Log
+	// Policy, when set, vets every mutating request before it is sent.
+	Policy Policy
+}
+
+// PlannedRequest is a mutating request that was not sent because of DryRun.
+type PlannedRequest struct {
+	Method string
+	URL    string
+	Body   interface{}
 }
 
 func NewClient(httpClient *http.Client) *Client {
@@ -49,18 +72,56 @@ func (c *Client) Delete(path string) error {
 	return err
 }
 
-func (c *Client) do(method, path string, body interface{}, result interface{}) (*models.PageDetail, error) {
+func (c *Client) do(method, path string, body interface{}, result interface{}) (page *models.PageDetail, err error) {
 	url := c.BaseURL + path
 
 	var bodyReader io.Reader
+	var reqData []byte
 	if body != nil {
-		data, err := json.Marshal(body)
+		reqData, err = json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("marshaling request body: %w", err)
 		}
-		bodyReader = bytes.NewReader(data)
+		bodyReader = bytes.NewReader(reqData)
 		if c.Verbose {
-			fmt.Printf("> Body: %s\n", string(data))
+			fmt.Printf("> Body: %s\n", string(reqData))
+		}
+	}
+
+	var status int
+	var respData json.RawMessage
+	if isMutation(method, path) {
+		if c.ReadOnly {
+			return nil, fmt.Errorf("%s %s: %w", method, path, ErrReadOnly)
+		}
+
+		var before json.RawMessage
+		if c.Audit != nil || c.Policy != nil {
+			before = c.snapshot(method, path, reqData)
+		}
+
+		var overridden []string
+		if c.Policy != nil {
+			overridden, err = c.Policy.Check(&Mutation{Method: method, Path: path, Body: body, Before: before})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// A dry run answers with the request body, so commands that send
+		// several requests carry on and every request is planned.
+		if c.DryRun {
+			c.Planned = append(c.Planned, PlannedRequest{Method: method, URL: url, Body: body})
+			if result != nil && reqData != nil {
+				_ = json.Unmarshal(reqData, result)
+			}
+			return nil, nil
+		}
+
+		if c.Audit != nil {
+			defer func() {
+				c.record(method, path, reqData, before, overridden, status, respData, err)
+			}()
 		}
 	}
 
@@ -78,6 +139,8 @@ func (c *Client) do(method, path string, body interface{}, result interface{}) (
 	}
 	defer resp.Body.Close()
 
+	status = resp.StatusCode
+
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("reading response: %w", err)
@@ -102,9 +165,9 @@ func (c *Client) do(method, path string, body interface{}, result interface{}) (
 	}
 
 	if apiResp.Error != nil && len(apiResp.Error.Errors) > 0 {
-		e := apiResp.Error.Errors[0]
-		return nil, fmt.Errorf("API error [%s]: %s", e.MessageCode, e.Message)
+		return nil, &Error{StatusCode: resp.StatusCode, Errors: apiResp.Error.Errors}
 	}
+	respData = apiResp.Data
 
 	if result != nil && apiResp.Data != nil {
 		if err := json.Unmarshal(apiResp.Data, result); err != nil {
@@ -115,13 +178,45 @@ func (c *Client) do(method, path string, body interface{}, result interface{}) (
 	return apiResp.Pagination, nil
 }
 
+// Error is an error returned by the API. Errors holds the individual
+// messages, including the offending Field for validation failures.
+type Error struct {
+	StatusCode int
+	Errors     []models.APIError
+	Body       string
+}
+
+func (e *Error) Error() string {
+	if len(e.Errors) == 0 {
+		return fmt.Sprintf("API error (HTTP %d): %s", e.StatusCode, truncate(e.Body, 500))
+	}
+	first := e.Errors[0]
+	if e.StatusCode >= 200 && e.StatusCode < 300 {
+		return fmt.Sprintf("API error [%s]: %s", first.MessageCode, first.Message)
+	}
+	return fmt.Sprintf("API error (HTTP %d) [%s]: %s", e.StatusCode, first.MessageCode, first.Message)
+}
+
 func parseError(statusCode int, body []byte) error {
 	var apiResp models.APIResponse
 	if err := json.Unmarshal(body, &apiResp); err == nil && apiResp.Error != nil && len(apiResp.Error.Errors) > 0 {
-		e := apiResp.Error.Errors[0]
-		return fmt.Errorf("API error (HTTP %d) [%s]: %s", statusCode, e.MessageCode, e.Message)
+		return &Error{StatusCode: statusCode, Errors: apiResp.Error.Errors}
+	}
+	return &Error{StatusCode: statusCode, Body: string(body)}
+}
+
+// isMutation reports whether a request changes account state. Find and
+// report queries are POSTs but only read data, and so is requesting a custom
+// (impression share) report.
+func isMutation(method, path string) bool {
+	switch method {
+	case http.MethodGet:
+		return false
+	case http.MethodPost:
+		p := strings.SplitN(path, "?", 2)[0]
+		return !strings.HasSuffix(p, "/find") && !strings.HasPrefix(p, "/reports/") && p != "/custom-reports"
 	}
-	return fmt.Errorf("API error (HTTP %d): %s", statusCode, truncate(string(body), 500))
+	return true
 }
 
 func truncate(s string, max int) string {
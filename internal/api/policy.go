@@ -0,0 +1,18 @@
+package api
+
+import "encoding/json"
+
+// Mutation is a mutating request about to be sent. Before holds the affected
+// entities as fetched just before the request, when they could be fetched.
+type Mutation struct {
+	Method string
+	Path   string
+	Body   interface{}
+	Before json.RawMessage
+}
+
+// Policy vets mutations before they are sent. Check returns an error to block
+// the request, or the violations it let through because they were overridden.
+type Policy interface {
+	Check(m *Mutation) (overridden []string, err error)
+}
@@ -0,0 +1,213 @@
+package audit
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/trebuhs/asa-cli/internal/config"
+)
+
+// Entry is one mutating API request recorded in the audit log. Before holds
+// the affected entities as fetched just before the request was sent.
+type Entry struct {
+	ID        string          `json:"id"`
+	Time      time.Time       `json:"time"`
+	Profile   string          `json:"profile"`
+	OrgID     string          `json:"orgId"`
+	User      string          `json:"user"`
+	Command   string          `json:"command"`
+	Method    string          `json:"method"`
+	Path      string          `json:"path"`
+	Request   json.RawMessage `json:"request,omitempty"`
+	Before    json.RawMessage `json:"before,omitempty"`
+	Status    int             `json:"status"`
+	EntityIDs []int64         `json:"entityIds,omitempty"`
+	Error     string          `json:"error,omitempty"`
+
+	// Overridden lists guardrail violations let through for this request,
+	// and OverrideReason the justification given for them.
+	Overridden     []string `json:"overridden,omitempty"`
+	OverrideReason string   `json:"overrideReason,omitempty"`
+}
+
+// Log appends entries to a JSONL file. Profile, OrgID, User and Command are
+// stamped on every entry it records, and OverrideReason on entries with
+// overridden guardrails.
+type Log struct {
+	Path           string
+	Profile        string
+	OrgID          string
+	User           string
+	Command        string
+	OverrideReason string
+}
+
+// DefaultPath is the audit log location under the config directory.
+func DefaultPath() string {
+	return filepath.Join(config.ConfigDir(), "audit.jsonl")
+}
+
+// NewLog creates a log at DefaultPath for the current OS user and command line.
+func NewLog(profile, orgID string) *Log {
+	if profile == "" {
+		profile = "default"
+	}
+	return &Log{
+		Path:    DefaultPath(),
+		Profile: profile,
+		OrgID:   orgID,
+		User:    currentUser(),
+		Command: strings.Join(os.Args, " "),
+	}
+}
+
+func currentUser() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
+
+// Record stamps and appends an entry, returning its ID.
+func (l *Log) Record(e Entry) (string, error) {
+	if e.ID == "" {
+		e.ID = newID()
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+	e.Profile = l.Profile
+	e.OrgID = l.OrgID
+	e.User = l.User
+	e.Command = l.Command
+	if len(e.Overridden) > 0 {
+		e.OverrideReason = l.OverrideReason
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return "", fmt.Errorf("encoding audit entry: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(l.Path), 0700); err != nil {
+		return "", fmt.Errorf("creating audit log directory: %w", err)
+	}
+	f, err := os.OpenFile(l.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return "", fmt.Errorf("opening audit log: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return "", fmt.Errorf("writing audit log: %w", err)
+	}
+	return e.ID, nil
+}
+
+// newID returns a sortable, unique change ID such as "20240102T150405-a1b2c3".
+func newID() string {
+	b := make([]byte, 3)
+	_, _ = rand.Read(b)
+	return time.Now().UTC().Format("20060102T150405") + "-" + hex.EncodeToString(b)
+}
+
+// Read loads every entry from an audit log. A missing log has no entries.
+func Read(path string) ([]Entry, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("opening audit log: %w", err)
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("parsing audit log line %d: %w", line, err)
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading audit log: %w", err)
+	}
+	return entries, nil
+}
+
+// Query filters audit entries. Zero-valued fields match everything.
+type Query struct {
+	Since  time.Time
+	Until  time.Time
+	Entity string
+	User   string
+}
+
+// Match reports whether an entry satisfies the query. Entity matches an
+// affected entity ID or any part of the request path (e.g. "campaigns/123").
+func (q Query) Match(e Entry) bool {
+	if !q.Since.IsZero() && e.Time.Before(q.Since) {
+		return false
+	}
+	if !q.Until.IsZero() && !e.Time.Before(q.Until) {
+		return false
+	}
+	if q.User != "" && !strings.EqualFold(q.User, e.User) {
+		return false
+	}
+	if q.Entity != "" {
+		if id, err := strconv.ParseInt(q.Entity, 10, 64); err == nil {
+			for _, eid := range e.EntityIDs {
+				if eid == id {
+					return true
+				}
+			}
+			for _, part := range strings.Split(e.Path, "/") {
+				if part == q.Entity {
+					return true
+				}
+			}
+			return false
+		}
+		return strings.Contains(e.Path, q.Entity)
+	}
+	return true
+}
+
+// Find returns the entry with the given change ID.
+func Find(entries []Entry, id string) (*Entry, error) {
+	for i := range entries {
+		if entries[i].ID == id {
+			return &entries[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no change %q in audit log", id)
+}
+
+// Filter returns the entries matching the query, oldest first.
+func Filter(entries []Entry, q Query) []Entry {
+	var out []Entry
+	for _, e := range entries {
+		if q.Match(e) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
@@ -14,8 +14,19 @@ type Config struct {
 	KeyID          string  `mapstructure:"key_id"`
 	OrgID          string  `mapstructure:"org_id"`
 	PrivateKeyPath string  `mapstructure:"private_key_path"`
+	ReadOnly       bool    `mapstructure:"read_only"`
 	MaxDailyBudget float64 `mapstructure:"max_daily_budget"`
 	MaxBid         float64 `mapstructure:"max_bid"`
+	MaxBudget      float64 `mapstructure:"max_budget"`
+	MaxCpaGoal     float64 `mapstructure:"max_cpa_goal"`
+
+	// Guardrails. Unlike the caps above they are not skipped by --force;
+	// violations need an override reason, which is recorded in the audit log.
+	MaxBidIncreasePct     float64 `mapstructure:"max_bid_increase_pct"`
+	MaxBudgetIncreasePct  float64 `mapstructure:"max_budget_increase_pct"`
+	MinBid                float64 `mapstructure:"min_bid"`
+	MaxTotalDailyBudget   float64 `mapstructure:"max_total_daily_budget"`
+	MaxEntitiesPerCommand int     `mapstructure:"max_entities_per_command"`
 }
 
 var (
@@ -122,6 +133,72 @@ func (c *Config) CheckBid(amount float64) error {
 	return nil
 }
 
+// CheckBudget validates a campaign total budget against the configured limit.
+// Returns nil if no limit is set or the amount is within the limit.
+func (c *Config) CheckBudget(amount float64) error {
+	if c.MaxBudget > 0 && amount > c.MaxBudget {
+		return fmt.Errorf("budget %.2f exceeds configured max_budget (%.2f). Update max_budget in %s/config.yaml or use --force to override",
+			amount, c.MaxBudget, ConfigDir())
+	}
+	return nil
+}
+
+// CheckCpaGoal validates an ad group CPA goal against the configured limit.
+// Returns nil if no limit is set or the amount is within the limit.
+func (c *Config) CheckCpaGoal(amount float64) error {
+	if c.MaxCpaGoal > 0 && amount > c.MaxCpaGoal {
+		return fmt.Errorf("CPA goal %.2f exceeds configured max_cpa_goal (%.2f). Update max_cpa_goal in %s/config.yaml or use --force to override",
+			amount, c.MaxCpaGoal, ConfigDir())
+	}
+	return nil
+}
+
+// CheckBidIncrease validates a bid change against max_bid_increase_pct.
+func (c *Config) CheckBidIncrease(old, new float64) error {
+	if c.MaxBidIncreasePct > 0 && old > 0 && new > old*(1+c.MaxBidIncreasePct/100) {
+		return fmt.Errorf("bid %.2f -> %.2f is a %.0f%% increase, above max_bid_increase_pct (%.0f%%)",
+			old, new, (new/old-1)*100, c.MaxBidIncreasePct)
+	}
+	return nil
+}
+
+// CheckBudgetIncrease validates a daily budget change against max_budget_increase_pct.
+func (c *Config) CheckBudgetIncrease(old, new float64) error {
+	if c.MaxBudgetIncreasePct > 0 && old > 0 && new > old*(1+c.MaxBudgetIncreasePct/100) {
+		return fmt.Errorf("daily budget %.2f -> %.2f is a %.0f%% increase, above max_budget_increase_pct (%.0f%%)",
+			old, new, (new/old-1)*100, c.MaxBudgetIncreasePct)
+	}
+	return nil
+}
+
+// CheckMinBid validates a bid amount against min_bid.
+func (c *Config) CheckMinBid(amount float64) error {
+	if c.MinBid > 0 && amount < c.MinBid {
+		return fmt.Errorf("bid %.2f is below min_bid (%.2f)", amount, c.MinBid)
+	}
+	return nil
+}
+
+// CheckTotalDailyBudget validates the summed daily budget of all enabled
+// campaigns against max_total_daily_budget.
+func (c *Config) CheckTotalDailyBudget(total float64) error {
+	if c.MaxTotalDailyBudget > 0 && total > c.MaxTotalDailyBudget {
+		return fmt.Errorf("total daily budget of enabled campaigns would be %.2f, above max_total_daily_budget (%.2f)",
+			total, c.MaxTotalDailyBudget)
+	}
+	return nil
+}
+
+// CheckEntityCount validates the number of entities one command changes
+// against max_entities_per_command.
+func (c *Config) CheckEntityCount(n int) error {
+	if c.MaxEntitiesPerCommand > 0 && n > c.MaxEntitiesPerCommand {
+		return fmt.Errorf("command would change %d entities, above max_entities_per_command (%d)",
+			n, c.MaxEntitiesPerCommand)
+	}
+	return nil
+}
+
 func Save(cfg *Config, profile string) error {
 	dir := ConfigDir()
 	if err := os.MkdirAll(dir, 0700); err != nil {
@@ -0,0 +1,248 @@
+// Package daypart converts between human-readable weekly schedules such as
+// "mon-fri 08:00-22:00; sat,sun 10:00-23:00" and the hour-of-week list used
+// by ad group daypart targeting, where hour 0 is Sunday 00:00-01:00 and hour
+// 167 is Saturday 23:00-24:00 in the user's time zone.
+package daypart
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// HoursPerWeek is the number of hours in the daypart grid.
+const HoursPerWeek = 168
+
+var (
+	dayNames     = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+	fullDayNames = []string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"}
+)
+
+// displayOrder lists days Monday first, as schedules are usually written.
+var displayOrder = []int{1, 2, 3, 4, 5, 6, 0}
+
+// Parse converts a schedule into sorted hours of the week. Entries are
+// separated by ";" and each is a set of days followed by one or more hour
+// ranges: "mon-fri 08:00-22:00", "sat,sun 10:00-12:00,14:00-23:00",
+// "daily 06:00-24:00". A range ending before it starts runs past midnight.
+func Parse(schedule string) ([]int, error) {
+	set := map[int]bool{}
+	for _, entry := range strings.Split(schedule, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		fields := strings.Fields(entry)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid schedule entry %q (expected e.g. \"mon-fri 08:00-22:00\")", entry)
+		}
+		days, err := parseDays(fields[0])
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range strings.Split(fields[1], ",") {
+			start, end, err := parseRange(r)
+			if err != nil {
+				return nil, err
+			}
+			// A range past midnight carries into the next day, and
+			// Saturday carries into Sunday. Equal ends mean the whole day.
+			length := (end - start + 24) % 24
+			if length == 0 {
+				length = 24
+			}
+			for _, d := range days {
+				for i := 0; i < length; i++ {
+					set[(d*24+start+i)%HoursPerWeek] = true
+				}
+			}
+		}
+	}
+	if len(set) == 0 {
+		return nil, fmt.Errorf("schedule %q selects no hours", schedule)
+	}
+
+	hours := make([]int, 0, len(set))
+	for h := range set {
+		hours = append(hours, h)
+	}
+	sort.Ints(hours)
+	return hours, nil
+}
+
+func parseDays(s string) ([]int, error) {
+	s = strings.ToLower(s)
+	if s == "daily" || s == "all" {
+		return []int{0, 1, 2, 3, 4, 5, 6}, nil
+	}
+	var days []int
+	for _, part := range strings.Split(s, ",") {
+		from, to, isRange := strings.Cut(part, "-")
+		first, err := dayIndex(from)
+		if err != nil {
+			return nil, err
+		}
+		if !isRange {
+			days = append(days, first)
+			continue
+		}
+		last, err := dayIndex(to)
+		if err != nil {
+			return nil, err
+		}
+		// Ranges wrap around the end of the week, so "fri-mon" covers the weekend.
+		for d := first; ; d = (d + 1) % 7 {
+			days = append(days, d)
+			if d == last {
+				break
+			}
+		}
+	}
+	return days, nil
+}
+
+func dayIndex(s string) (int, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for i, name := range dayNames {
+		if s == name || s == fullDayNames[i] {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid day %q (expected mon, tue, ... sun)", s)
+}
+
+// parseRange parses "08:00-22:00" into a start hour and an exclusive end
+// hour, 0-23. "24:00" as end is the same as "00:00".
+func parseRange(s string) (int, int, error) {
+	from, to, ok := strings.Cut(strings.TrimSpace(s), "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid hour range %q (expected e.g. 08:00-22:00)", s)
+	}
+	start, err := parseHour(from)
+	if err != nil {
+		return 0, 0, err
+	}
+	end, err := parseHour(to)
+	if err != nil {
+		return 0, 0, err
+	}
+	if start == 24 {
+		return 0, 0, fmt.Errorf("invalid hour range %q: a range cannot start at 24:00", s)
+	}
+	return start, end % 24, nil
+}
+
+func parseHour(s string) (int, error) {
+	h, m, hasMinutes := strings.Cut(s, ":")
+	hour, err := strconv.Atoi(h)
+	if err != nil || hour < 0 || hour > 24 || (hasMinutes && m != "00") {
+		return 0, fmt.Errorf("invalid time %q (daypart targeting uses whole hours, e.g. 08:00)", s)
+	}
+	return hour, nil
+}
+
+// Format renders hours of the week as a schedule that Parse accepts, grouping
+// days with the same hours. An empty or full week is "all".
+func Format(hours []int) string {
+	if len(hours) == 0 || len(hours) == HoursPerWeek {
+		return "all"
+	}
+	var perDay [7][24]bool
+	for _, h := range hours {
+		if h >= 0 && h < HoursPerWeek {
+			perDay[h/24][h%24] = true
+		}
+	}
+
+	var entries []string
+	done := map[int]bool{}
+	for _, d := range displayOrder {
+		if done[d] {
+			continue
+		}
+		ranges := formatRanges(perDay[d])
+		var days []int
+		for _, other := range displayOrder {
+			if !done[other] && perDay[other] == perDay[d] {
+				days = append(days, other)
+				done[other] = true
+			}
+		}
+		if ranges == "" {
+			continue
+		}
+		entries = append(entries, formatDays(days)+" "+ranges)
+	}
+	return strings.Join(entries, "; ")
+}
+
+func formatRanges(day [24]bool) string {
+	var ranges []string
+	for h := 0; h < 24; {
+		if !day[h] {
+			h++
+			continue
+		}
+		start := h
+		for h < 24 && day[h] {
+			h++
+		}
+		ranges = append(ranges, fmt.Sprintf("%02d:00-%02d:00", start, h))
+	}
+	return strings.Join(ranges, ",")
+}
+
+// formatDays joins days in display order, collapsing runs of three or more
+// consecutive days into a range.
+func formatDays(days []int) string {
+	if len(days) == 7 {
+		return "daily"
+	}
+	pos := map[int]int{}
+	for i, d := range displayOrder {
+		pos[d] = i
+	}
+	var parts []string
+	for i := 0; i < len(days); {
+		j := i
+		for j+1 < len(days) && pos[days[j+1]] == pos[days[j]]+1 {
+			j++
+		}
+		if j-i >= 2 {
+			parts = append(parts, dayNames[days[i]]+"-"+dayNames[days[j]])
+		} else {
+			for k := i; k <= j; k++ {
+				parts = append(parts, dayNames[days[k]])
+			}
+		}
+		i = j + 1
+	}
+	return strings.Join(parts, ",")
+}
+
+// Grid renders hours of the week as a Monday-first weekly grid with one
+// line per day and one column per hour. An empty list means every hour.
+func Grid(hours []int) []string {
+	on := map[int]bool{}
+	for _, h := range hours {
+		on[h] = true
+	}
+	lines := []string{"     00    04    08    12    16    20"}
+	for _, d := range displayOrder {
+		var b strings.Builder
+		b.WriteString(strings.ToUpper(dayNames[d][:1]) + dayNames[d][1:] + "  ")
+		for h := 0; h < 24; h++ {
+			if len(hours) == 0 || on[d*24+h] {
+				b.WriteString("#")
+			} else {
+				b.WriteString(".")
+			}
+			if h%4 == 3 && h != 23 {
+				b.WriteString("  ")
+			}
+		}
+		lines = append(lines, b.String())
+	}
+	return lines
+}
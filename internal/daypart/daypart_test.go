@@ -0,0 +1,27 @@
+package daypart
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseOvernight(t *testing.T) {
+	tests := []struct {
+		schedule string
+		want     []int
+	}{
+		{"fri 22:00-02:00", []int{142, 143, 144, 145}},
+		{"sat 23:00-01:00", []int{0, 167}},
+		{"mon 08:00-10:00", []int{32, 33}},
+		{"sun 00:00-24:00", []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23}},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.schedule)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.schedule, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Parse(%q) = %v, want %v", tt.schedule, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package daypart
+
+// Stats are the spend and installs of one hour of the week, summed over the
+// days of an hourly report.
+type Stats struct {
+	Spend    float64
+	Installs int64
+}
+
+// Suggest picks the hours worth bidding in from hourly stats: an hour is
+// dropped when it spent at least one average CPI without an install, or when
+// its CPI is more than maxRatio times the average. Hours without spend are
+// kept, as there is no evidence against them. It returns nil when the stats
+// have no installs to compare against.
+func Suggest(stats [HoursPerWeek]Stats, maxRatio float64) []int {
+	var spend float64
+	var installs int64
+	for _, s := range stats {
+		spend += s.Spend
+		installs += s.Installs
+	}
+	if installs == 0 {
+		return nil
+	}
+	avgCPI := spend / float64(installs)
+
+	var hours []int
+	for h, s := range stats {
+		switch {
+		case s.Spend == 0:
+		case s.Installs == 0:
+			if s.Spend >= avgCPI {
+				continue
+			}
+		case s.Spend/float64(s.Installs) > maxRatio*avgCPI:
+			continue
+		}
+		hours = append(hours, h)
+	}
+	return hours
+}
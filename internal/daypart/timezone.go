@@ -0,0 +1,39 @@
+package daypart
+
+// countryTimeZones holds storefronts that use a single time zone, so a
+// campaign targeting one of them has a known user time. Countries spanning
+// several zones (US, CA, AU, BR, MX, RU, ...) are left out.
+var countryTimeZones = map[string]string{
+	"AT": "Europe/Vienna",
+	"BE": "Europe/Brussels",
+	"CH": "Europe/Zurich",
+	"CN": "Asia/Shanghai",
+	"CZ": "Europe/Prague",
+	"DE": "Europe/Berlin",
+	"DK": "Europe/Copenhagen",
+	"ES": "Europe/Madrid",
+	"FI": "Europe/Helsinki",
+	"FR": "Europe/Paris",
+	"GB": "Europe/London",
+	"GR": "Europe/Athens",
+	"HK": "Asia/Hong_Kong",
+	"IE": "Europe/Dublin",
+	"IN": "Asia/Kolkata",
+	"IT": "Europe/Rome",
+	"JP": "Asia/Tokyo",
+	"KR": "Asia/Seoul",
+	"NL": "Europe/Amsterdam",
+	"NO": "Europe/Oslo",
+	"NZ": "Pacific/Auckland",
+	"PL": "Europe/Warsaw",
+	"PT": "Europe/Lisbon",
+	"SE": "Europe/Stockholm",
+	"SG": "Asia/Singapore",
+	"TW": "Asia/Taipei",
+}
+
+// CountryTimeZone returns the IANA time zone of a single-zone storefront.
+func CountryTimeZone(country string) (string, bool) {
+	tz, ok := countryTimeZones[country]
+	return tz, ok
+}
@@ -0,0 +1,276 @@
+// Package lint checks account structure for problems that cost money or
+// reach, and suggests a fix for each finding.
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/trebuhs/asa-cli/internal/models"
+	"github.com/trebuhs/asa-cli/internal/state"
+)
+
+// Keyword rules.
+const (
+	RuleDuplicateKeyword     = "duplicate-keyword"
+	RuleMissingExactNegative = "missing-exact-negative"
+	RuleBlockedByNegative    = "blocked-by-negative"
+)
+
+// Finding is one problem, with the entities involved and a suggested fix.
+type Finding struct {
+	Rule    string   `json:"rule"`
+	Subject string   `json:"subject"`
+	Where   []string `json:"where"`
+	Fix     string   `json:"fix"`
+}
+
+// placement is a live ad group with the campaign it belongs to.
+type placement struct {
+	campaign *state.Campaign
+	adGroup  *state.AdGroup
+}
+
+func (p placement) label() string {
+	return fmt.Sprintf("%s / %s (%d)", p.campaign.Campaign.Name, p.adGroup.AdGroup.Name, p.adGroup.AdGroup.ID)
+}
+
+// compete reports whether two campaigns advertise the same app in a common
+// storefront, so their ad groups bid in the same auctions.
+func compete(a, b *state.Campaign) bool {
+	if a.Campaign.AdamID != b.Campaign.AdamID {
+		return false
+	}
+	for _, x := range a.Campaign.CountriesOrRegions {
+		for _, y := range b.Campaign.CountriesOrRegions {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Keywords checks the targeting keywords of live (enabled) campaigns and ad
+// groups for duplicates across ad groups serving the same app and storefront,
+// EXACT keywords that discovery ad groups do not negate, and keywords blocked
+// by a negative keyword. Findings are ordered by rule, then subject.
+func Keywords(account *state.Account) []Finding {
+	var live []placement
+	for i := range account.Campaigns {
+		c := &account.Campaigns[i]
+		if c.Campaign.Status != "ENABLED" {
+			continue
+		}
+		for j := range c.AdGroups {
+			if c.AdGroups[j].AdGroup.Status == "ENABLED" {
+				live = append(live, placement{campaign: c, adGroup: &c.AdGroups[j]})
+			}
+		}
+	}
+
+	var findings []Finding
+	findings = append(findings, duplicates(live)...)
+	findings = append(findings, missingExactNegatives(live)...)
+	findings = append(findings, blockedByNegatives(live)...)
+	sortFindings(findings)
+	return findings
+}
+
+func sortFindings(findings []Finding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Rule != findings[j].Rule {
+			return findings[i].Rule < findings[j].Rule
+		}
+		return findings[i].Subject < findings[j].Subject
+	})
+}
+
+func activeKeywords(ag *state.AdGroup) []models.Keyword {
+	var active []models.Keyword
+	for _, kw := range ag.Keywords {
+		if kw.Status == "ACTIVE" && !kw.Deleted {
+			active = append(active, kw)
+		}
+	}
+	return active
+}
+
+func normalize(text string) string {
+	return strings.Join(strings.Fields(strings.ToLower(text)), " ")
+}
+
+// duplicates finds the same text and match type targeted by more than one
+// ad group in campaigns that compete (same app and storefront).
+func duplicates(live []placement) []Finding {
+	type group struct {
+		text, matchType string
+		members         []placement
+	}
+	groups := map[string]*group{}
+	var order []string
+	for _, p := range live {
+		for _, kw := range activeKeywords(p.adGroup) {
+			key := models.Key(kw.Text, kw.MatchType)
+			g, ok := groups[key]
+			if !ok {
+				g = &group{text: kw.Text, matchType: kw.MatchType}
+				groups[key] = g
+				order = append(order, key)
+			}
+			g.members = append(g.members, p)
+		}
+	}
+
+	var findings []Finding
+	for _, key := range order {
+		g := groups[key]
+		for _, cluster := range competing(g.members) {
+			var where []string
+			for _, p := range cluster {
+				where = append(where, p.label())
+			}
+			findings = append(findings, Finding{
+				Rule:    RuleDuplicateKeyword,
+				Subject: fmt.Sprintf("%q %s", g.text, g.matchType),
+				Where:   where,
+				Fix:     fmt.Sprintf("keep %q in one ad group; pause it in the others or add it there as an EXACT negative", g.text),
+			})
+		}
+	}
+	return findings
+}
+
+// competing splits placements into clusters that compete in the same
+// auctions and returns the clusters with more than one member.
+func competing(members []placement) [][]placement {
+	var clusters [][]placement
+	assigned := make([]bool, len(members))
+	for i := range members {
+		if assigned[i] {
+			continue
+		}
+		cluster := []placement{members[i]}
+		assigned[i] = true
+		for j := i + 1; j < len(members); j++ {
+			if !assigned[j] && compete(members[i].campaign, members[j].campaign) {
+				cluster = append(cluster, members[j])
+				assigned[j] = true
+			}
+		}
+		if len(cluster) > 1 {
+			clusters = append(clusters, cluster)
+		}
+	}
+	return clusters
+}
+
+// isDiscovery reports whether an ad group matches queries loosely: search
+// match is on or it has BROAD keywords.
+func isDiscovery(ag *state.AdGroup) bool {
+	if ag.AdGroup.AutomatedKeywordsOptIn {
+		return true
+	}
+	for _, kw := range activeKeywords(ag) {
+		if kw.MatchType == "BROAD" {
+			return true
+		}
+	}
+	return false
+}
+
+// negatedExactly reports whether text is an active EXACT negative of the ad
+// group or its campaign. Deleted negatives do not count.
+func negatedExactly(p placement, text string) bool {
+	negatives := append(append([]models.NegativeKeyword(nil), p.campaign.NegativeKeywords...), p.adGroup.NegativeKeywords...)
+	for _, nk := range negatives {
+		if nk.Status != "PAUSED" && !nk.Deleted && models.Key(nk.Text, nk.MatchType) == models.Key(text, "EXACT") {
+			return true
+		}
+	}
+	return false
+}
+
+// missingExactNegatives finds EXACT keywords that a discovery ad group in a
+// competing campaign does not negate, so it can win the same queries at its
+// own bid.
+func missingExactNegatives(live []placement) []Finding {
+	var findings []Finding
+	for _, d := range live {
+		if !isDiscovery(d.adGroup) {
+			continue
+		}
+		for _, p := range live {
+			if p.adGroup == d.adGroup || !compete(p.campaign, d.campaign) {
+				continue
+			}
+			for _, kw := range activeKeywords(p.adGroup) {
+				if kw.MatchType != "EXACT" || negatedExactly(d, kw.Text) {
+					continue
+				}
+				findings = append(findings, Finding{
+					Rule:    RuleMissingExactNegative,
+					Subject: fmt.Sprintf("%q EXACT", kw.Text),
+					Where:   []string{"targeted in " + p.label(), "not negated in " + d.label()},
+					Fix: fmt.Sprintf("asa-cli negative-keywords adgroup-create --campaign-id %d --adgroup-id %d --text %q --match-type EXACT",
+						d.campaign.Campaign.ID, d.adGroup.AdGroup.ID, kw.Text),
+				})
+			}
+		}
+	}
+	return findings
+}
+
+// blocks reports whether a negative keyword stops a keyword from serving on
+// its own text: an EXACT negative blocks the same text, a BROAD negative
+// blocks any text containing all of its words.
+func blocks(nk models.NegativeKeyword, text string) bool {
+	if nk.MatchType == "EXACT" {
+		return normalize(nk.Text) == normalize(text)
+	}
+	words := map[string]bool{}
+	for _, w := range strings.Fields(normalize(text)) {
+		words[w] = true
+	}
+	for _, w := range strings.Fields(normalize(nk.Text)) {
+		if !words[w] {
+			return false
+		}
+	}
+	return true
+}
+
+// blockedByNegatives finds active keywords that a negative keyword of the
+// same ad group or campaign blocks.
+func blockedByNegatives(live []placement) []Finding {
+	var findings []Finding
+	for _, p := range live {
+		check := func(level string, negatives []models.NegativeKeyword, fix string) {
+			for _, nk := range negatives {
+				if nk.Status == "PAUSED" || nk.Deleted {
+					continue
+				}
+				for _, kw := range activeKeywords(p.adGroup) {
+					if !blocks(nk, kw.Text) {
+						continue
+					}
+					findings = append(findings, Finding{
+						Rule:    RuleBlockedByNegative,
+						Subject: fmt.Sprintf("%q %s", kw.Text, kw.MatchType),
+						Where: []string{"targeted in " + p.label(),
+							fmt.Sprintf("blocked by %s negative %q %s (%d)", level, nk.Text, nk.MatchType, nk.ID)},
+						Fix: fmt.Sprintf(fix, nk.ID),
+					})
+				}
+			}
+		}
+		check("campaign", p.campaign.NegativeKeywords, fmt.Sprintf(
+			"pause the negative (asa-cli negative-keywords campaign-update %%d --campaign-id %d --status PAUSED) or pause the keyword",
+			p.campaign.Campaign.ID))
+		check("ad group", p.adGroup.NegativeKeywords, fmt.Sprintf(
+			"pause the negative (asa-cli negative-keywords adgroup-update %%d --campaign-id %d --adgroup-id %d --status PAUSED) or pause the keyword",
+			p.campaign.Campaign.ID, p.adGroup.AdGroup.ID))
+	}
+	return findings
+}
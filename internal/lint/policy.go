@@ -0,0 +1,96 @@
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/trebuhs/asa-cli/internal/config"
+	"go.yaml.in/yaml/v3"
+)
+
+// Policy configures the structure rules. A zero value for a rule turns it off.
+type Policy struct {
+	// CampaignName and AdGroupName are regular expressions names must match.
+	CampaignName string `yaml:"campaign_name"`
+	AdGroupName  string `yaml:"ad_group_name"`
+	// Countries is "single" to require one country per campaign or
+	// "multiple" to require more than one.
+	Countries string `yaml:"countries"`
+	// RequireDefaultBid flags ad groups without a default bid.
+	RequireDefaultBid bool `yaml:"require_default_bid"`
+	// NoSearchMatchInExact flags ad groups with search match on in campaigns
+	// whose keywords are all EXACT.
+	NoSearchMatchInExact bool `yaml:"no_search_match_in_exact"`
+	// NotRunning flags enabled campaigns that are not serving.
+	NotRunning bool `yaml:"not_running"`
+	// MinBudgetBidRatio flags campaigns whose daily budget is below their
+	// highest bid times this ratio.
+	MinBudgetBidRatio float64 `yaml:"min_budget_bid_ratio"`
+
+	campaignName *regexp.Regexp
+	adGroupName  *regexp.Regexp
+}
+
+// DefaultPolicy is used when there is no policy file. Naming and country
+// rules are account-specific, so they are off.
+func DefaultPolicy() *Policy {
+	return &Policy{
+		RequireDefaultBid:    true,
+		NoSearchMatchInExact: true,
+		NotRunning:           true,
+		MinBudgetBidRatio:    10,
+	}
+}
+
+// DefaultPolicyPath is the policy file used when none is given.
+func DefaultPolicyPath() string {
+	return filepath.Join(config.ConfigDir(), "lint-policy.yaml")
+}
+
+// LoadPolicy reads a policy file. Rules the file does not mention keep
+// their defaults. When optional is set, a missing file yields the default
+// policy.
+func LoadPolicy(path string, optional bool) (*Policy, error) {
+	p := DefaultPolicy()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if optional && errors.Is(err, fs.ErrNotExist) {
+			return p, p.compile()
+		}
+		return nil, fmt.Errorf("reading lint policy: %w", err)
+	}
+	if err := yaml.Unmarshal(data, p); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if err := p.compile(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return p, nil
+}
+
+func (p *Policy) compile() error {
+	var err error
+	if p.CampaignName != "" {
+		if p.campaignName, err = regexp.Compile(p.CampaignName); err != nil {
+			return fmt.Errorf("invalid campaign_name: %w", err)
+		}
+	}
+	if p.AdGroupName != "" {
+		if p.adGroupName, err = regexp.Compile(p.AdGroupName); err != nil {
+			return fmt.Errorf("invalid ad_group_name: %w", err)
+		}
+	}
+	switch p.Countries {
+	case "", "single", "multiple":
+	default:
+		return fmt.Errorf("invalid countries %q (expected single or multiple)", p.Countries)
+	}
+	if p.MinBudgetBidRatio < 0 {
+		return fmt.Errorf("min_budget_bid_ratio must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/trebuhs/asa-cli/internal/state"
+)
+
+// Structure rules.
+const (
+	RuleCampaignName       = "campaign-name"
+	RuleAdGroupName        = "ad-group-name"
+	RuleCountries          = "countries"
+	RuleMissingDefaultBid  = "missing-default-bid"
+	RuleSearchMatchInExact = "search-match-in-exact"
+	RuleNotRunning         = "not-running"
+	RuleBudgetBelowBid     = "budget-below-bid"
+)
+
+// Structure checks campaigns and ad groups against the policy. Findings are
+// ordered by rule, then subject.
+func Structure(account *state.Account, p *Policy) []Finding {
+	var findings []Finding
+	for i := range account.Campaigns {
+		c := &account.Campaigns[i]
+		findings = append(findings, checkCampaign(c, p)...)
+		for j := range c.AdGroups {
+			findings = append(findings, checkAdGroup(placement{campaign: c, adGroup: &c.AdGroups[j]}, p)...)
+		}
+	}
+	sortFindings(findings)
+	return findings
+}
+
+func campaignLabel(c *state.Campaign) string {
+	return fmt.Sprintf("%s (%d)", c.Campaign.Name, c.Campaign.ID)
+}
+
+func checkCampaign(c *state.Campaign, p *Policy) []Finding {
+	var findings []Finding
+	camp := c.Campaign
+	subject := campaignLabel(c)
+
+	if p.campaignName != nil && !p.campaignName.MatchString(camp.Name) {
+		findings = append(findings, Finding{
+			Rule:    RuleCampaignName,
+			Subject: subject,
+			Where:   []string{fmt.Sprintf("name does not match %s", p.CampaignName)},
+			Fix:     fmt.Sprintf("asa-cli campaigns update %d --name <name>", camp.ID),
+		})
+	}
+
+	switch n := len(camp.CountriesOrRegions); {
+	case p.Countries == "single" && n > 1:
+		findings = append(findings, Finding{
+			Rule:    RuleCountries,
+			Subject: subject,
+			Where:   []string{fmt.Sprintf("targets %d countries: %s", n, strings.Join(camp.CountriesOrRegions, ","))},
+			Fix: fmt.Sprintf("split it with asa-cli campaigns clone %d --countries %s, then pause the original",
+				camp.ID, strings.Join(camp.CountriesOrRegions, ",")),
+		})
+	case p.Countries == "multiple" && n == 1:
+		findings = append(findings, Finding{
+			Rule:    RuleCountries,
+			Subject: subject,
+			Where:   []string{"targets only " + camp.CountriesOrRegions[0]},
+			Fix:     "merge it into a campaign for the same app that targets several countries",
+		})
+	}
+
+	if p.NotRunning && camp.Status == "ENABLED" && camp.ServingStatus == "NOT_RUNNING" {
+		where := camp.ServingStateReasons
+		if len(where) == 0 {
+			where = []string{"no reason given"}
+		}
+		findings = append(findings, Finding{
+			Rule:    RuleNotRunning,
+			Subject: subject,
+			Where:   where,
+			Fix:     fmt.Sprintf("asa-cli diagnose --campaigns id=%d explains each reason, or pause it (asa-cli campaigns update %d --status PAUSED)", camp.ID, camp.ID),
+		})
+	}
+
+	if p.MinBudgetBidRatio > 0 && camp.DailyBudgetAmount != nil {
+		daily := camp.DailyBudgetAmount.Float()
+		bid, where := highestBid(c)
+		if bid > 0 && daily < bid*p.MinBudgetBidRatio {
+			findings = append(findings, Finding{
+				Rule:    RuleBudgetBelowBid,
+				Subject: subject,
+				Where: []string{
+					fmt.Sprintf("daily budget %.2f %s", daily, camp.DailyBudgetAmount.Currency),
+					fmt.Sprintf("highest bid %.2f in %s", bid, where),
+				},
+				Fix: fmt.Sprintf("asa-cli campaigns update %d --daily-budget %.2f, or lower the bid",
+					camp.ID, bid*p.MinBudgetBidRatio),
+			})
+		}
+	}
+	return findings
+}
+
+func checkAdGroup(pl placement, p *Policy) []Finding {
+	var findings []Finding
+	ag := pl.adGroup.AdGroup
+	subject := pl.label()
+
+	if p.adGroupName != nil && !p.adGroupName.MatchString(ag.Name) {
+		findings = append(findings, Finding{
+			Rule:    RuleAdGroupName,
+			Subject: subject,
+			Where:   []string{fmt.Sprintf("name does not match %s", p.AdGroupName)},
+			Fix:     fmt.Sprintf("asa-cli adgroups update %d --campaign-id %d --name <name>", ag.ID, pl.campaign.Campaign.ID),
+		})
+	}
+
+	if p.RequireDefaultBid && (ag.DefaultBidAmount == nil || ag.DefaultBidAmount.Float() <= 0) {
+		findings = append(findings, Finding{
+			Rule:    RuleMissingDefaultBid,
+			Subject: subject,
+			Where:   []string{"no default bid"},
+			Fix:     fmt.Sprintf("asa-cli adgroups update %d --campaign-id %d --default-bid <amount>", ag.ID, pl.campaign.Campaign.ID),
+		})
+	}
+
+	if p.NoSearchMatchInExact && ag.AutomatedKeywordsOptIn && exactOnly(pl.campaign) {
+		findings = append(findings, Finding{
+			Rule:    RuleSearchMatchInExact,
+			Subject: subject,
+			Where:   []string{fmt.Sprintf("search match is on, but every keyword in %s is EXACT", campaignLabel(pl.campaign))},
+			Fix:     fmt.Sprintf("asa-cli adgroups update %d --campaign-id %d --auto-keywords false", ag.ID, pl.campaign.Campaign.ID),
+		})
+	}
+	return findings
+}
+
+// exactOnly reports whether a campaign has keywords and all of them are EXACT.
+func exactOnly(c *state.Campaign) bool {
+	found := false
+	for i := range c.AdGroups {
+		for _, kw := range activeKeywords(&c.AdGroups[i]) {
+			if kw.MatchType != "EXACT" {
+				return false
+			}
+			found = true
+		}
+	}
+	return found
+}
+
+// highestBid returns the highest bid in a campaign's active ad groups and
+// keywords, and where it is set. Keywords without a bid use their ad
+// group's default bid.
+func highestBid(c *state.Campaign) (float64, string) {
+	var max float64
+	var where string
+	for i := range c.AdGroups {
+		ag := &c.AdGroups[i]
+		if ag.AdGroup.Status != "ENABLED" {
+			continue
+		}
+		if v := ag.AdGroup.DefaultBidAmount.Float(); v > max {
+			max, where = v, fmt.Sprintf("ad group %q (default bid)", ag.AdGroup.Name)
+		}
+		for _, kw := range activeKeywords(ag) {
+			if v := kw.BidAmount.Float(); v > max {
+				max, where = v, fmt.Sprintf("ad group %q, keyword %q", ag.AdGroup.Name, kw.Text)
+			}
+		}
+	}
+	return max, where
+}
@@ -0,0 +1,291 @@
+package manifest
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/trebuhs/asa-cli/internal/api"
+	"github.com/trebuhs/asa-cli/internal/models"
+	"github.com/trebuhs/asa-cli/internal/services"
+)
+
+// Applier executes a plan against the API.
+type Applier struct {
+	Client   *api.Client
+	Currency string
+	// OnChange, if set, is called after each change has been applied.
+	OnChange func(c Change)
+
+	campaignIDs map[string]int64
+	adGroupIDs  map[string]int64
+}
+
+// NewApplier creates an Applier that sends amounts in the given currency.
+func NewApplier(client *api.Client, currency string) *Applier {
+	return &Applier{
+		Client:      client,
+		Currency:    currency,
+		campaignIDs: map[string]int64{},
+		adGroupIDs:  map[string]int64{},
+	}
+}
+
+// Apply executes the plan in order and stops at the first failure. Keyword
+// and negative keyword changes for the same ad group are sent as bulk
+// requests of at most services.KeywordBulkLimit keywords.
+func (a *Applier) Apply(plan *Plan) error {
+	changes := plan.Changes
+	for i := 0; i < len(changes); {
+		c := changes[i]
+		if c.Kind == KindCampaign || c.Kind == KindAdGroup {
+			if err := a.applyOne(c); err != nil {
+				return fmt.Errorf("%s %s %q: %w", c.Action, c.Kind, c.Target(), err)
+			}
+			a.notify(c)
+			i++
+			continue
+		}
+
+		j := i + 1
+		for j < len(changes) && sameBatch(c, changes[j]) {
+			j++
+		}
+		for _, batch := range services.Chunk(changes[i:j], services.KeywordBulkLimit) {
+			if err := a.applyBatch(batch); err != nil {
+				return fmt.Errorf("%s %d %s(s) in %q: %w", c.Action, len(batch), c.Kind, c.Target(), err)
+			}
+			for _, b := range batch {
+				a.notify(b)
+			}
+		}
+		i = j
+	}
+	return nil
+}
+
+func (a *Applier) notify(c Change) {
+	if a.OnChange != nil {
+		a.OnChange(c)
+	}
+}
+
+func sameBatch(a, b Change) bool {
+	return a.Kind == b.Kind && batchAction(a.Action) == batchAction(b.Action) &&
+		a.Campaign == b.Campaign && a.AdGroup == b.AdGroup
+}
+
+func batchAction(action Action) Action {
+	if action == ActionPause {
+		return ActionUpdate
+	}
+	return action
+}
+
+func (a *Applier) campaignID(c Change) int64 {
+	if c.CampaignID != 0 {
+		return c.CampaignID
+	}
+	return a.campaignIDs[c.Campaign]
+}
+
+func (a *Applier) adGroupID(c Change) int64 {
+	if c.AdGroupID != 0 {
+		return c.AdGroupID
+	}
+	return a.adGroupIDs[c.Campaign+"\x00"+c.AdGroup]
+}
+
+func (a *Applier) money(amount string) *models.Money {
+	if amount == "" {
+		return nil
+	}
+	return &models.Money{Amount: amount, Currency: a.Currency}
+}
+
+func (a *Applier) applyOne(c Change) error {
+	switch c.Kind {
+	case KindCampaign:
+		svc := services.NewCampaignService(a.Client)
+		switch c.Action {
+		case ActionCreate:
+			created, err := svc.Create(a.newCampaign(c.campaign))
+			if err != nil {
+				return err
+			}
+			a.campaignIDs[c.Campaign] = created.ID
+			return nil
+		case ActionDelete:
+			return svc.Delete(c.CampaignID)
+		default:
+			_, err := svc.Update(c.CampaignID, a.campaignUpdate(c.Fields))
+			return err
+		}
+
+	case KindAdGroup:
+		svc := services.NewAdGroupService(a.Client)
+		campaignID := a.campaignID(c)
+		switch c.Action {
+		case ActionCreate:
+			created, err := svc.Create(campaignID, a.newAdGroup(c.adGroup))
+			if err != nil {
+				return err
+			}
+			a.adGroupIDs[c.Campaign+"\x00"+c.AdGroup] = created.ID
+			return nil
+		case ActionDelete:
+			return svc.Delete(campaignID, c.ID)
+		default:
+			_, err := svc.Update(campaignID, c.ID, a.adGroupUpdate(c.Fields))
+			return err
+		}
+	}
+	return fmt.Errorf("unsupported change kind %q", c.Kind)
+}
+
+func (a *Applier) applyBatch(batch []Change) error {
+	first := batch[0]
+	svc := services.NewKeywordService(a.Client)
+	campaignID := a.campaignID(first)
+	adGroupID := a.adGroupID(first)
+
+	var ids []int64
+	for _, c := range batch {
+		ids = append(ids, c.ID)
+	}
+
+	switch first.Kind {
+	case KindKeyword:
+		switch batchAction(first.Action) {
+		case ActionCreate:
+			var keywords []models.Keyword
+			for _, c := range batch {
+				kw := models.Keyword{
+					Text:      c.keyword.Text,
+					MatchType: c.keyword.MatchType,
+					Status:    strings.ToUpper(c.keyword.Status),
+					BidAmount: a.money(c.keyword.Bid),
+				}
+				keywords = append(keywords, kw)
+			}
+			_, err := svc.Create(campaignID, adGroupID, keywords)
+			return err
+		case ActionUpdate:
+			var updates []models.KeywordUpdate
+			for _, c := range batch {
+				update := models.KeywordUpdate{ID: c.ID}
+				for _, f := range c.Fields {
+					switch f.Field {
+					case "bid":
+						update.BidAmount = a.money(f.New)
+					case "status":
+						update.Status = f.New
+					}
+				}
+				updates = append(updates, update)
+			}
+			_, err := svc.Update(campaignID, adGroupID, updates)
+			return err
+		case ActionDelete:
+			return svc.Delete(campaignID, adGroupID, ids)
+		}
+
+	case KindCampaignNegative, KindAdGroupNegative:
+		switch first.Action {
+		case ActionCreate:
+			var keywords []models.NegativeKeyword
+			for _, c := range batch {
+				keywords = append(keywords, models.NegativeKeyword{Text: c.negative.Text, MatchType: c.negative.MatchType})
+			}
+			var err error
+			if first.Kind == KindCampaignNegative {
+				_, err = svc.CreateCampaignNegativeKeywords(campaignID, keywords)
+			} else {
+				_, err = svc.CreateAdGroupNegativeKeywords(campaignID, adGroupID, keywords)
+			}
+			return err
+		case ActionDelete:
+			if first.Kind == KindCampaignNegative {
+				return svc.DeleteCampaignNegativeKeywords(campaignID, ids)
+			}
+			return svc.DeleteAdGroupNegativeKeywords(campaignID, adGroupID, ids)
+		}
+	}
+	return fmt.Errorf("unsupported %s of %s", first.Action, first.Kind)
+}
+
+func (a *Applier) newCampaign(spec *CampaignSpec) *models.Campaign {
+	status := strings.ToUpper(spec.Status)
+	if status == "" {
+		status = "ENABLED"
+	}
+	return &models.Campaign{
+		Name:               spec.Name,
+		AdamID:             spec.AppID,
+		Status:             status,
+		CountriesOrRegions: spec.Countries,
+		BudgetAmount:       a.money(spec.Budget),
+		DailyBudgetAmount:  a.money(spec.DailyBudget),
+		AdChannelType:      "SEARCH",
+		SupplySources:      []string{"APPSTORE_SEARCH_RESULTS"},
+		BillingEvent:       "TAPS",
+	}
+}
+
+func (a *Applier) campaignUpdate(fields []FieldChange) *models.CampaignUpdate {
+	update := &models.CampaignUpdate{}
+	for _, f := range fields {
+		switch f.Field {
+		case "budget":
+			update.BudgetAmount = a.money(f.New)
+		case "daily_budget":
+			update.DailyBudgetAmount = a.money(f.New)
+		case "status":
+			update.Status = f.New
+		case "countries":
+			update.CountriesOrRegions = strings.Split(f.New, ",")
+		}
+	}
+	return update
+}
+
+func (a *Applier) newAdGroup(spec *AdGroupSpec) *models.AdGroup {
+	status := strings.ToUpper(spec.Status)
+	if status == "" {
+		status = "ENABLED"
+	}
+	adgroup := &models.AdGroup{
+		Name:             spec.Name,
+		Status:           status,
+		DefaultBidAmount: a.money(spec.DefaultBid),
+		CpaGoal:          a.money(spec.CpaGoal),
+		StartTime:        spec.StartTime,
+		EndTime:          spec.EndTime,
+		PricingModel:     "CPC",
+	}
+	if spec.AutoKeywords != nil {
+		adgroup.AutomatedKeywordsOptIn = *spec.AutoKeywords
+	}
+	return adgroup
+}
+
+func (a *Applier) adGroupUpdate(fields []FieldChange) *models.AdGroupUpdate {
+	update := &models.AdGroupUpdate{}
+	for _, f := range fields {
+		switch f.Field {
+		case "default_bid":
+			update.DefaultBidAmount = a.money(f.New)
+		case "cpa_goal":
+			update.CpaGoal = a.money(f.New)
+		case "status":
+			update.Status = f.New
+		case "auto_keywords":
+			val := f.New == "true"
+			update.AutomatedKeywordsOptIn = &val
+		case "start_time":
+			update.StartTime = f.New
+		case "end_time":
+			update.EndTime = f.New
+		}
+	}
+	return update
+}
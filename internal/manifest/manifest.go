@@ -0,0 +1,180 @@
+package manifest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/trebuhs/asa-cli/internal/models"
+	"go.yaml.in/yaml/v3"
+)
+
+// Manifest is the desired account structure described in YAML.
+//
+// Only campaigns listed in a manifest are managed. Within a managed campaign,
+// a collection that is listed (ad_groups, keywords, negative_keywords, even
+// as an empty list) is authoritative: live entries missing from it are
+// deleted. Collections that are left out are not touched.
+type Manifest struct {
+	Campaigns []CampaignSpec `yaml:"campaigns"`
+}
+
+// CampaignSpec describes a campaign. Campaigns are matched to live state by name.
+type CampaignSpec struct {
+	Name             string                `yaml:"name"`
+	AppID            int64                 `yaml:"app_id"`
+	Countries        []string              `yaml:"countries"`
+	Budget           string                `yaml:"budget"`
+	DailyBudget      string                `yaml:"daily_budget"`
+	Status           string                `yaml:"status"`
+	Delete           bool                  `yaml:"delete"`
+	NegativeKeywords []NegativeKeywordSpec `yaml:"negative_keywords"`
+	AdGroups         []AdGroupSpec         `yaml:"ad_groups"`
+}
+
+// AdGroupSpec describes an ad group. Ad groups are matched by name within their campaign.
+type AdGroupSpec struct {
+	Name             string                `yaml:"name"`
+	DefaultBid       string                `yaml:"default_bid"`
+	CpaGoal          string                `yaml:"cpa_goal"`
+	Status           string                `yaml:"status"`
+	AutoKeywords     *bool                 `yaml:"auto_keywords"`
+	StartTime        string                `yaml:"start_time"`
+	EndTime          string                `yaml:"end_time"`
+	Delete           bool                  `yaml:"delete"`
+	Keywords         []KeywordSpec         `yaml:"keywords"`
+	NegativeKeywords []NegativeKeywordSpec `yaml:"negative_keywords"`
+}
+
+// KeywordSpec describes a targeting keyword, matched by text and match type.
+type KeywordSpec struct {
+	Text      string `yaml:"text"`
+	MatchType string `yaml:"match_type"`
+	Bid       string `yaml:"bid"`
+	Status    string `yaml:"status"`
+}
+
+// NegativeKeywordSpec describes a negative keyword, matched by text and match type.
+type NegativeKeywordSpec struct {
+	Text      string `yaml:"text"`
+	MatchType string `yaml:"match_type"`
+}
+
+// Load reads one or more manifest files or directories of *.yaml/*.yml files
+// and merges their campaigns into a single manifest.
+func Load(paths []string) (*Manifest, error) {
+	var files []string
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		if err != nil {
+			return nil, fmt.Errorf("reading manifest: %w", err)
+		}
+		if !info.IsDir() {
+			files = append(files, p)
+			continue
+		}
+		for _, pattern := range []string{"*.yaml", "*.yml"} {
+			matches, err := filepath.Glob(filepath.Join(p, pattern))
+			if err != nil {
+				return nil, fmt.Errorf("listing manifests in %s: %w", p, err)
+			}
+			files = append(files, matches...)
+		}
+	}
+	sort.Strings(files)
+
+	m := &Manifest{}
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("reading manifest: %w", err)
+		}
+		var part Manifest
+		if err := yaml.Unmarshal(data, &part); err != nil {
+			return nil, fmt.Errorf("parsing manifest %s: %w", f, err)
+		}
+		m.Campaigns = append(m.Campaigns, part.Campaigns...)
+	}
+
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Validate checks required fields and rejects duplicate identities.
+func (m *Manifest) Validate() error {
+	campaigns := map[string]bool{}
+	for i := range m.Campaigns {
+		c := &m.Campaigns[i]
+		if c.Name == "" {
+			return fmt.Errorf("campaign #%d: name is required", i+1)
+		}
+		if campaigns[c.Name] {
+			return fmt.Errorf("campaign %q is declared more than once", c.Name)
+		}
+		campaigns[c.Name] = true
+
+		if err := validateNegatives(c.NegativeKeywords, c.Name); err != nil {
+			return err
+		}
+
+		adgroups := map[string]bool{}
+		for j := range c.AdGroups {
+			ag := &c.AdGroups[j]
+			if ag.Name == "" {
+				return fmt.Errorf("campaign %q: ad group #%d: name is required", c.Name, j+1)
+			}
+			if adgroups[ag.Name] {
+				return fmt.Errorf("campaign %q: ad group %q is declared more than once", c.Name, ag.Name)
+			}
+			adgroups[ag.Name] = true
+
+			where := c.Name + " / " + ag.Name
+			keywords := map[string]bool{}
+			for k := range ag.Keywords {
+				kw := &ag.Keywords[k]
+				kw.MatchType = normalizeMatchType(kw.MatchType)
+				if kw.Text == "" {
+					return fmt.Errorf("%s: keyword #%d: text is required", where, k+1)
+				}
+				key := models.Key(kw.Text, kw.MatchType)
+				if keywords[key] {
+					return fmt.Errorf("%s: keyword %q (%s) is declared more than once", where, kw.Text, kw.MatchType)
+				}
+				keywords[key] = true
+			}
+			if err := validateNegatives(ag.NegativeKeywords, where); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateNegatives(negatives []NegativeKeywordSpec, where string) error {
+	seen := map[string]bool{}
+	for i := range negatives {
+		nk := &negatives[i]
+		nk.MatchType = normalizeMatchType(nk.MatchType)
+		if nk.Text == "" {
+			return fmt.Errorf("%s: negative keyword #%d: text is required", where, i+1)
+		}
+		key := models.Key(nk.Text, nk.MatchType)
+		if seen[key] {
+			return fmt.Errorf("%s: negative keyword %q (%s) is declared more than once", where, nk.Text, nk.MatchType)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// normalizeMatchType defaults an empty match type to EXACT and upper-cases it.
+func normalizeMatchType(matchType string) string {
+	if matchType == "" {
+		return "EXACT"
+	}
+	return strings.ToUpper(matchType)
+}
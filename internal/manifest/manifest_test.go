@@ -0,0 +1,77 @@
+package manifest
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		m       Manifest
+		wantErr string
+	}{
+		{
+			name: "valid",
+			m: Manifest{Campaigns: []CampaignSpec{{
+				Name:             "Brand",
+				NegativeKeywords: []NegativeKeywordSpec{{Text: "free"}},
+				AdGroups: []AdGroupSpec{{
+					Name:     "Exact",
+					Keywords: []KeywordSpec{{Text: "habit tracker"}, {Text: "habit tracker", MatchType: "broad"}},
+				}},
+			}}},
+		},
+		{
+			name:    "campaign without name",
+			m:       Manifest{Campaigns: []CampaignSpec{{}}},
+			wantErr: "campaign #1: name is required",
+		},
+		{
+			name:    "duplicate campaign",
+			m:       Manifest{Campaigns: []CampaignSpec{{Name: "Brand"}, {Name: "Brand"}}},
+			wantErr: `campaign "Brand" is declared more than once`,
+		},
+		{
+			name:    "duplicate ad group",
+			m:       Manifest{Campaigns: []CampaignSpec{{Name: "Brand", AdGroups: []AdGroupSpec{{Name: "Exact"}, {Name: "Exact"}}}}},
+			wantErr: `ad group "Exact" is declared more than once`,
+		},
+		{
+			name: "duplicate keyword differing in case and match type case",
+			m: Manifest{Campaigns: []CampaignSpec{{Name: "Brand", AdGroups: []AdGroupSpec{{
+				Name:     "Exact",
+				Keywords: []KeywordSpec{{Text: "Habit Tracker", MatchType: "exact"}, {Text: "habit  tracker"}},
+			}}}}},
+			wantErr: `keyword "habit  tracker" (EXACT) is declared more than once`,
+		},
+		{
+			name: "keyword without text",
+			m: Manifest{Campaigns: []CampaignSpec{{Name: "Brand", AdGroups: []AdGroupSpec{{
+				Name:     "Exact",
+				Keywords: []KeywordSpec{{MatchType: "EXACT"}},
+			}}}}},
+			wantErr: "Brand / Exact: keyword #1: text is required",
+		},
+		{
+			name:    "duplicate campaign negative",
+			m:       Manifest{Campaigns: []CampaignSpec{{Name: "Brand", NegativeKeywords: []NegativeKeywordSpec{{Text: "free"}, {Text: "FREE", MatchType: "EXACT"}}}}},
+			wantErr: `Brand: negative keyword "FREE" (EXACT) is declared more than once`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.m.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Validate() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
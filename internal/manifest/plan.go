@@ -0,0 +1,433 @@
+package manifest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/trebuhs/asa-cli/internal/models"
+	"github.com/trebuhs/asa-cli/internal/state"
+)
+
+// Action is what apply will do to an entity.
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionPause  Action = "pause"
+	ActionDelete Action = "delete"
+)
+
+// Kind is the type of entity a change applies to.
+type Kind string
+
+const (
+	KindCampaign         Kind = "campaign"
+	KindAdGroup          Kind = "adgroup"
+	KindKeyword          Kind = "keyword"
+	KindCampaignNegative Kind = "campaign-negative"
+	KindAdGroupNegative  Kind = "adgroup-negative"
+)
+
+// FieldChange is a single attribute that differs between desired and live state.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// Change is one step of a plan. IDs are those of live entities and are zero
+// for entities that will be created by an earlier step.
+type Change struct {
+	Action     Action        `json:"action"`
+	Kind       Kind          `json:"kind"`
+	Campaign   string        `json:"campaign"`
+	AdGroup    string        `json:"adGroup,omitempty"`
+	Keyword    string        `json:"keyword,omitempty"`
+	MatchType  string        `json:"matchType,omitempty"`
+	CampaignID int64         `json:"campaignId,omitempty"`
+	AdGroupID  int64         `json:"adGroupId,omitempty"`
+	ID         int64         `json:"id,omitempty"`
+	Fields     []FieldChange `json:"fields,omitempty"`
+
+	campaign *CampaignSpec
+	adGroup  *AdGroupSpec
+	keyword  *KeywordSpec
+	negative *NegativeKeywordSpec
+}
+
+// Target renders the entity a change applies to, e.g. "Brand US / Exact / habit tracker (EXACT)".
+func (c Change) Target() string {
+	parts := []string{c.Campaign}
+	if c.AdGroup != "" {
+		parts = append(parts, c.AdGroup)
+	}
+	if c.Keyword != "" {
+		parts = append(parts, fmt.Sprintf("%s (%s)", c.Keyword, c.MatchType))
+	}
+	return strings.Join(parts, " / ")
+}
+
+// Plan is the ordered list of changes needed to reach the manifest.
+// Deletes come first, bottom-up; creates and updates follow, top-down.
+type Plan struct {
+	Changes []Change `json:"changes"`
+}
+
+// Count returns how many changes have the given action.
+func (p *Plan) Count(action Action) int {
+	n := 0
+	for _, c := range p.Changes {
+		if c.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// BuildPlan diffs the manifest against live state.
+func BuildPlan(m *Manifest, live *state.Account) (*Plan, error) {
+	var deletes, upserts []Change
+
+	for i := range m.Campaigns {
+		spec := &m.Campaigns[i]
+		current := live.FindCampaign(spec.Name)
+
+		if spec.Delete {
+			if current != nil {
+				deletes = append(deletes, Change{
+					Action: ActionDelete, Kind: KindCampaign,
+					Campaign: spec.Name, CampaignID: current.Campaign.ID,
+				})
+			}
+			continue
+		}
+
+		if current == nil {
+			if spec.AppID == 0 || len(spec.Countries) == 0 {
+				return nil, fmt.Errorf("campaign %q does not exist; app_id and countries are required to create it", spec.Name)
+			}
+			upserts = append(upserts, Change{Action: ActionCreate, Kind: KindCampaign, Campaign: spec.Name, campaign: spec})
+			upserts = append(upserts, planCampaignChildren(spec, nil)...)
+			continue
+		}
+
+		if spec.AppID != 0 && spec.AppID != current.Campaign.AdamID {
+			return nil, fmt.Errorf("campaign %q: app_id cannot be changed (live: %d, manifest: %d)", spec.Name, current.Campaign.AdamID, spec.AppID)
+		}
+
+		if fields := diffCampaign(spec, &current.Campaign); len(fields) > 0 {
+			upserts = append(upserts, Change{
+				Action: updateAction(fields), Kind: KindCampaign,
+				Campaign: spec.Name, CampaignID: current.Campaign.ID,
+				Fields: fields, campaign: spec,
+			})
+		}
+
+		deletes = append(deletes, planCampaignDeletes(spec, current)...)
+		upserts = append(upserts, planCampaignChildren(spec, current)...)
+	}
+
+	return &Plan{Changes: append(deletes, upserts...)}, nil
+}
+
+// planCampaignDeletes returns deletes for live children missing from the
+// spec. Only collections the spec lists are pruned; a nil slice means the
+// key was left out of the manifest.
+func planCampaignDeletes(spec *CampaignSpec, current *state.Campaign) []Change {
+	var changes []Change
+	campaignID := current.Campaign.ID
+
+	for _, ag := range current.AdGroups {
+		agSpec := findAdGroupSpec(spec, ag.AdGroup.Name)
+		if agSpec == nil && spec.AdGroups == nil {
+			continue
+		}
+		if agSpec == nil || agSpec.Delete {
+			changes = append(changes, Change{
+				Action: ActionDelete, Kind: KindAdGroup,
+				Campaign: spec.Name, AdGroup: ag.AdGroup.Name,
+				CampaignID: campaignID, ID: ag.AdGroup.ID,
+			})
+			continue
+		}
+
+		wanted := map[string]bool{}
+		for _, kw := range agSpec.Keywords {
+			wanted[models.Key(kw.Text, kw.MatchType)] = true
+		}
+		for _, kw := range ag.Keywords {
+			if agSpec.Keywords != nil && !wanted[models.Key(kw.Text, kw.MatchType)] {
+				changes = append(changes, Change{
+					Action: ActionDelete, Kind: KindKeyword,
+					Campaign: spec.Name, AdGroup: ag.AdGroup.Name,
+					Keyword: kw.Text, MatchType: kw.MatchType,
+					CampaignID: campaignID, AdGroupID: ag.AdGroup.ID, ID: kw.ID,
+				})
+			}
+		}
+
+		changes = append(changes, negativeDeletes(KindAdGroupNegative, spec.Name, ag.AdGroup.Name, campaignID, ag.AdGroup.ID, agSpec.NegativeKeywords, ag.NegativeKeywords)...)
+	}
+
+	changes = append(changes, negativeDeletes(KindCampaignNegative, spec.Name, "", campaignID, 0, spec.NegativeKeywords, current.NegativeKeywords)...)
+	return changes
+}
+
+func negativeDeletes(kind Kind, campaign, adGroup string, campaignID, adGroupID int64, specs []NegativeKeywordSpec, live []models.NegativeKeyword) []Change {
+	if specs == nil {
+		return nil
+	}
+	wanted := map[string]bool{}
+	for _, nk := range specs {
+		wanted[models.Key(nk.Text, nk.MatchType)] = true
+	}
+	var changes []Change
+	for _, nk := range live {
+		if !wanted[models.Key(nk.Text, nk.MatchType)] {
+			changes = append(changes, Change{
+				Action: ActionDelete, Kind: kind,
+				Campaign: campaign, AdGroup: adGroup,
+				Keyword: nk.Text, MatchType: nk.MatchType,
+				CampaignID: campaignID, AdGroupID: adGroupID, ID: nk.ID,
+			})
+		}
+	}
+	return changes
+}
+
+// planCampaignChildren returns creates and updates below a campaign.
+// current is nil when the campaign itself is being created.
+func planCampaignChildren(spec *CampaignSpec, current *state.Campaign) []Change {
+	var changes []Change
+	var campaignID int64
+	var liveNegatives []models.NegativeKeyword
+	if current != nil {
+		campaignID = current.Campaign.ID
+		liveNegatives = current.NegativeKeywords
+	}
+
+	changes = append(changes, negativeCreates(KindCampaignNegative, spec.Name, "", campaignID, 0, spec.NegativeKeywords, liveNegatives)...)
+
+	for i := range spec.AdGroups {
+		agSpec := &spec.AdGroups[i]
+		if agSpec.Delete {
+			continue
+		}
+
+		var liveAG *state.AdGroup
+		if current != nil {
+			liveAG = current.FindAdGroup(agSpec.Name)
+		}
+
+		var adGroupID int64
+		var liveKeywords []models.Keyword
+		var liveAGNegatives []models.NegativeKeyword
+		if liveAG == nil {
+			changes = append(changes, Change{
+				Action: ActionCreate, Kind: KindAdGroup,
+				Campaign: spec.Name, AdGroup: agSpec.Name,
+				CampaignID: campaignID, adGroup: agSpec,
+			})
+		} else {
+			adGroupID = liveAG.AdGroup.ID
+			liveKeywords = liveAG.Keywords
+			liveAGNegatives = liveAG.NegativeKeywords
+			if fields := diffAdGroup(agSpec, &liveAG.AdGroup); len(fields) > 0 {
+				changes = append(changes, Change{
+					Action: updateAction(fields), Kind: KindAdGroup,
+					Campaign: spec.Name, AdGroup: agSpec.Name,
+					CampaignID: campaignID, ID: adGroupID,
+					Fields: fields, adGroup: agSpec,
+				})
+			}
+		}
+
+		live := map[string]models.Keyword{}
+		for _, kw := range liveKeywords {
+			live[models.Key(kw.Text, kw.MatchType)] = kw
+		}
+		for j := range agSpec.Keywords {
+			kwSpec := &agSpec.Keywords[j]
+			change := Change{
+				Kind:     KindKeyword,
+				Campaign: spec.Name, AdGroup: agSpec.Name,
+				Keyword: kwSpec.Text, MatchType: kwSpec.MatchType,
+				CampaignID: campaignID, AdGroupID: adGroupID,
+				keyword: kwSpec,
+			}
+			existing, ok := live[models.Key(kwSpec.Text, kwSpec.MatchType)]
+			if !ok {
+				change.Action = ActionCreate
+				changes = append(changes, change)
+				continue
+			}
+			if fields := diffKeyword(kwSpec, &existing); len(fields) > 0 {
+				change.Action = updateAction(fields)
+				change.ID = existing.ID
+				change.Fields = fields
+				changes = append(changes, change)
+			}
+		}
+
+		changes = append(changes, negativeCreates(KindAdGroupNegative, spec.Name, agSpec.Name, campaignID, adGroupID, agSpec.NegativeKeywords, liveAGNegatives)...)
+	}
+
+	return changes
+}
+
+func negativeCreates(kind Kind, campaign, adGroup string, campaignID, adGroupID int64, specs []NegativeKeywordSpec, live []models.NegativeKeyword) []Change {
+	existing := map[string]bool{}
+	for _, nk := range live {
+		existing[models.Key(nk.Text, nk.MatchType)] = true
+	}
+	var changes []Change
+	for i := range specs {
+		nk := &specs[i]
+		if existing[models.Key(nk.Text, nk.MatchType)] {
+			continue
+		}
+		changes = append(changes, Change{
+			Action: ActionCreate, Kind: kind,
+			Campaign: campaign, AdGroup: adGroup,
+			Keyword: nk.Text, MatchType: nk.MatchType,
+			CampaignID: campaignID, AdGroupID: adGroupID,
+			negative: nk,
+		})
+	}
+	return changes
+}
+
+func findAdGroupSpec(spec *CampaignSpec, name string) *AdGroupSpec {
+	for i := range spec.AdGroups {
+		if spec.AdGroups[i].Name == name {
+			return &spec.AdGroups[i]
+		}
+	}
+	return nil
+}
+
+func diffCampaign(spec *CampaignSpec, live *models.Campaign) []FieldChange {
+	var fields []FieldChange
+	fields = diffMoney(fields, "budget", spec.Budget, live.BudgetAmount)
+	fields = diffMoney(fields, "daily_budget", spec.DailyBudget, live.DailyBudgetAmount)
+	fields = diffString(fields, "status", spec.Status, live.Status)
+	if len(spec.Countries) > 0 && !sameSet(spec.Countries, live.CountriesOrRegions) {
+		fields = append(fields, FieldChange{
+			Field: "countries",
+			Old:   strings.Join(live.CountriesOrRegions, ","),
+			New:   strings.Join(spec.Countries, ","),
+		})
+	}
+	return fields
+}
+
+func diffAdGroup(spec *AdGroupSpec, live *models.AdGroup) []FieldChange {
+	var fields []FieldChange
+	fields = diffMoney(fields, "default_bid", spec.DefaultBid, live.DefaultBidAmount)
+	fields = diffMoney(fields, "cpa_goal", spec.CpaGoal, live.CpaGoal)
+	fields = diffString(fields, "status", spec.Status, live.Status)
+	if spec.AutoKeywords != nil && *spec.AutoKeywords != live.AutomatedKeywordsOptIn {
+		fields = append(fields, FieldChange{
+			Field: "auto_keywords",
+			Old:   strconv.FormatBool(live.AutomatedKeywordsOptIn),
+			New:   strconv.FormatBool(*spec.AutoKeywords),
+		})
+	}
+	fields = diffTime(fields, "start_time", spec.StartTime, live.StartTime)
+	fields = diffTime(fields, "end_time", spec.EndTime, live.EndTime)
+	return fields
+}
+
+func diffKeyword(spec *KeywordSpec, live *models.Keyword) []FieldChange {
+	var fields []FieldChange
+	fields = diffMoney(fields, "bid", spec.Bid, live.BidAmount)
+	fields = diffString(fields, "status", spec.Status, live.Status)
+	return fields
+}
+
+// diffString records a change when want is set and differs from have.
+func diffString(fields []FieldChange, name, want, have string) []FieldChange {
+	if want != "" && !strings.EqualFold(want, have) {
+		fields = append(fields, FieldChange{Field: name, Old: have, New: strings.ToUpper(want)})
+	}
+	return fields
+}
+
+// diffTime records a change when want is set and is a different instant
+// from have. Times that do not parse are compared as text.
+func diffTime(fields []FieldChange, name, want, have string) []FieldChange {
+	if want == "" {
+		return fields
+	}
+	tw, okW := parseTime(want)
+	th, okH := parseTime(have)
+	if okW && okH && tw.Equal(th) || !(okW && okH) && want == have {
+		return fields
+	}
+	return append(fields, FieldChange{Field: name, Old: have, New: want})
+}
+
+// parseTime parses the API's timestamp layout and the shorter forms a
+// manifest may use.
+func parseTime(s string) (time.Time, bool) {
+	for _, layout := range []string{"2006-01-02T15:04:05.000", time.RFC3339, "2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// diffMoney records a change when want is set and differs numerically from have.
+func diffMoney(fields []FieldChange, name, want string, have *models.Money) []FieldChange {
+	if want == "" {
+		return fields
+	}
+	old := ""
+	if have != nil {
+		old = have.Amount
+	}
+	if !sameAmount(want, old) {
+		fields = append(fields, FieldChange{Field: name, Old: old, New: want})
+	}
+	return fields
+}
+
+func sameAmount(a, b string) bool {
+	fa, errA := strconv.ParseFloat(a, 64)
+	fb, errB := strconv.ParseFloat(b, 64)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return fa == fb
+}
+
+func sameSet(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	seen := map[string]int{}
+	for _, s := range a {
+		seen[strings.ToUpper(s)]++
+	}
+	for _, s := range b {
+		seen[strings.ToUpper(s)]--
+	}
+	for _, n := range seen {
+		if n != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// updateAction reports an update whose only effect is pausing as a pause.
+func updateAction(fields []FieldChange) Action {
+	if len(fields) == 1 && fields[0].Field == "status" && fields[0].New == "PAUSED" {
+		return ActionPause
+	}
+	return ActionUpdate
+}
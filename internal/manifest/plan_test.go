@@ -0,0 +1,181 @@
+package manifest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/trebuhs/asa-cli/internal/models"
+	"github.com/trebuhs/asa-cli/internal/state"
+)
+
+// usd returns an amount in US dollars.
+func usd(amount string) *models.Money {
+	return &models.Money{Amount: amount, Currency: "USD"}
+}
+
+// live returns an account with one campaign "Brand" holding the ad group
+// "Exact", one keyword and one negative keyword at each level.
+func live() *state.Account {
+	return &state.Account{Campaigns: []state.Campaign{{
+		Campaign: models.Campaign{
+			ID: 1, Name: "Brand", AdamID: 42, Status: "ENABLED",
+			CountriesOrRegions: []string{"US"}, DailyBudgetAmount: usd("50"),
+		},
+		NegativeKeywords: []models.NegativeKeyword{{ID: 10, Text: "free", MatchType: "EXACT"}},
+		AdGroups: []state.AdGroup{{
+			AdGroup: models.AdGroup{
+				ID: 2, Name: "Exact", Status: "ENABLED", DefaultBidAmount: usd("1.50"),
+				StartTime: "2026-10-01T00:00:00.000", EndTime: "2026-12-31T00:00:00.000",
+			},
+			Keywords:         []models.Keyword{{ID: 20, Text: "habit tracker", MatchType: "EXACT", Status: "ACTIVE", BidAmount: usd("1.00")}},
+			NegativeKeywords: []models.NegativeKeyword{{ID: 30, Text: "cheap", MatchType: "EXACT"}},
+		}},
+	}}}
+}
+
+// summary renders each change as "action kind target [fields]".
+func summary(p *Plan) []string {
+	var lines []string
+	for _, c := range p.Changes {
+		line := fmt.Sprintf("%s %s %s", c.Action, c.Kind, c.Target())
+		for _, f := range c.Fields {
+			line += fmt.Sprintf(" %s:%s->%s", f.Field, f.Old, f.New)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestBuildPlan(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    CampaignSpec
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "no changes when collections are left out",
+			spec: CampaignSpec{Name: "Brand", DailyBudget: "50.00"},
+		},
+		{
+			name: "empty lists prune everything below",
+			spec: CampaignSpec{
+				Name:             "Brand",
+				NegativeKeywords: []NegativeKeywordSpec{},
+				AdGroups: []AdGroupSpec{{
+					Name:             "Exact",
+					Keywords:         []KeywordSpec{},
+					NegativeKeywords: []NegativeKeywordSpec{},
+				}},
+			},
+			want: []string{
+				"delete keyword Brand / Exact / habit tracker (EXACT)",
+				"delete adgroup-negative Brand / Exact / cheap (EXACT)",
+				"delete campaign-negative Brand / free (EXACT)",
+			},
+		},
+		{
+			name: "ad group missing from a listed collection is deleted",
+			spec: CampaignSpec{Name: "Brand", AdGroups: []AdGroupSpec{{Name: "Broad"}}},
+			want: []string{
+				"delete adgroup Brand / Exact",
+				"create adgroup Brand / Broad",
+			},
+		},
+		{
+			name: "field updates and a pause",
+			spec: CampaignSpec{
+				Name:        "Brand",
+				DailyBudget: "75",
+				AdGroups: []AdGroupSpec{{
+					Name:       "Exact",
+					DefaultBid: "1.5",
+					Keywords:   []KeywordSpec{{Text: "Habit Tracker", MatchType: "EXACT", Status: "paused"}},
+				}},
+			},
+			want: []string{
+				"update campaign Brand daily_budget:50->75",
+				"pause keyword Brand / Exact / Habit Tracker (EXACT) status:ACTIVE->PAUSED",
+			},
+		},
+		{
+			name: "start and end time compare as instants",
+			spec: CampaignSpec{Name: "Brand", AdGroups: []AdGroupSpec{{
+				Name:      "Exact",
+				StartTime: "2026-10-01",
+				EndTime:   "2027-01-31T00:00:00Z",
+			}}},
+			want: []string{
+				"update adgroup Brand / Exact end_time:2026-12-31T00:00:00.000->2027-01-31T00:00:00Z",
+			},
+		},
+		{
+			name: "changed start time",
+			spec: CampaignSpec{Name: "Brand", AdGroups: []AdGroupSpec{{Name: "Exact", StartTime: "2026-11-01T00:00"}}},
+			want: []string{
+				"update adgroup Brand / Exact start_time:2026-10-01T00:00:00.000->2026-11-01T00:00",
+			},
+		},
+		{
+			name: "delete campaign",
+			spec: CampaignSpec{Name: "Brand", Delete: true},
+			want: []string{"delete campaign Brand"},
+		},
+		{
+			name: "new campaign with children",
+			spec: CampaignSpec{
+				Name: "Generic", AppID: 42, Countries: []string{"US"},
+				NegativeKeywords: []NegativeKeywordSpec{{Text: "free", MatchType: "EXACT"}},
+				AdGroups: []AdGroupSpec{{
+					Name:     "Broad",
+					Keywords: []KeywordSpec{{Text: "habits", MatchType: "BROAD"}},
+				}},
+			},
+			want: []string{
+				"create campaign Generic",
+				"create campaign-negative Generic / free (EXACT)",
+				"create adgroup Generic / Broad",
+				"create keyword Generic / Broad / habits (BROAD)",
+			},
+		},
+		{
+			name:    "new campaign without app_id",
+			spec:    CampaignSpec{Name: "Generic", Countries: []string{"US"}},
+			wantErr: "app_id and countries are required",
+		},
+		{
+			name:    "new campaign without countries",
+			spec:    CampaignSpec{Name: "Generic", AppID: 42},
+			wantErr: "app_id and countries are required",
+		},
+		{
+			name:    "app_id cannot change",
+			spec:    CampaignSpec{Name: "Brand", AppID: 43},
+			wantErr: "app_id cannot be changed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Manifest{Campaigns: []CampaignSpec{tt.spec}}
+			if err := m.Validate(); err != nil {
+				t.Fatalf("Validate: %v", err)
+			}
+			plan, err := BuildPlan(m, live())
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("BuildPlan() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("BuildPlan: %v", err)
+			}
+			if got := summary(plan); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("plan =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
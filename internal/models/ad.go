@@ -0,0 +1,25 @@
+package models
+
+// Ad attaches a creative (default product page, custom product page or
+// creative set) to an ad group.
+type Ad struct {
+	ID                  int64    `json:"id,omitempty"`
+	OrgID               int64    `json:"orgId,omitempty"`
+	CampaignID          int64    `json:"campaignId,omitempty"`
+	AdGroupID           int64    `json:"adGroupId,omitempty"`
+	CreativeID          int64    `json:"creativeId,omitempty"`
+	CreativeType        string   `json:"creativeType,omitempty"`
+	Name                string   `json:"name"`
+	Status              string   `json:"status,omitempty"`
+	ServingStatus       string   `json:"servingStatus,omitempty"`
+	ServingStateReasons []string `json:"servingStateReasons,omitempty"`
+	Deleted             bool     `json:"deleted,omitempty"`
+	CreationTime        string   `json:"creationTime,omitempty"`
+	ModificationTime    string   `json:"modificationTime,omitempty"`
+}
+
+// AdUpdate contains fields that can be updated on an ad.
+type AdUpdate struct {
+	Name   string `json:"name,omitempty"`
+	Status string `json:"status,omitempty"`
+}
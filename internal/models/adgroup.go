@@ -15,28 +15,54 @@ type AdGroup struct {
 	AutomatedKeywordsOptIn bool   `json:"automatedKeywordsOptIn,omitempty"`
 	StartTime             string   `json:"startTime,omitempty"`
 	EndTime               string   `json:"endTime,omitempty"`
+	Deleted               bool     `json:"deleted,omitempty"`
 	ModificationTime      string   `json:"modificationTime,omitempty"`
 	TargetingDimensions   *TargetingDimensions `json:"targetingDimensions,omitempty"`
 	PaymentModel          string   `json:"paymentModel,omitempty"`
 	PricingModel          string   `json:"pricingModel,omitempty"`
 }
 
-// TargetingDimensions for ad group targeting.
+// TargetingDimensions for ad group targeting. A nil dimension means no
+// restriction; it is sent as null so that an update removes the restriction
+// rather than leaving it unchanged.
 type TargetingDimensions struct {
-	Age            *TargetingDimension `json:"age,omitempty"`
-	Gender         *TargetingDimension `json:"gender,omitempty"`
-	DeviceClass    *TargetingDimension `json:"deviceClass,omitempty"`
-	Locality       *TargetingDimension `json:"locality,omitempty"`
-	AdminArea      *TargetingDimension `json:"adminArea,omitempty"`
-	Country        *TargetingDimension `json:"country,omitempty"`
-	AppDownloaders *TargetingDimension `json:"appDownloaders,omitempty"`
-	DayPart        *TargetingDimension `json:"daypart,omitempty"`
+	Age            *AgeCriteria     `json:"age"`
+	Gender         *StringCriteria  `json:"gender"`
+	DeviceClass    *StringCriteria  `json:"deviceClass"`
+	Locality       *StringCriteria  `json:"locality"`
+	AdminArea      *StringCriteria  `json:"adminArea"`
+	Country        *StringCriteria  `json:"country"`
+	AppDownloaders *StringCriteria  `json:"appDownloaders"`
+	DayPart        *DayPartCriteria `json:"daypart"`
 }
 
-// TargetingDimension is a single targeting dimension.
-type TargetingDimension struct {
-	Included []interface{} `json:"included,omitempty"`
-	Excluded []interface{} `json:"excluded,omitempty"`
+// StringCriteria includes or excludes values such as device classes
+// (IPHONE, IPAD), genders (M, F), geo IDs (US|CA) or app Adam IDs.
+type StringCriteria struct {
+	Included []string `json:"included,omitempty"`
+	Excluded []string `json:"excluded,omitempty"`
+}
+
+// AgeCriteria targets one or more age ranges.
+type AgeCriteria struct {
+	Included []AgeRange `json:"included,omitempty"`
+}
+
+// AgeRange is an age range; a zero MaxAge means no upper bound.
+type AgeRange struct {
+	MinAge int `json:"minAge,omitempty"`
+	MaxAge int `json:"maxAge,omitempty"`
+}
+
+// DayPartCriteria targets hours of the week in the user's time zone.
+type DayPartCriteria struct {
+	UserTime *DayPartDetail `json:"userTime,omitempty"`
+}
+
+// DayPartDetail lists the included hours of the week, 0-167, where 0 is
+// Sunday 00:00-01:00.
+type DayPartDetail struct {
+	Included []int `json:"included,omitempty"`
 }
 
 // AdGroupUpdate contains fields that can be updated on an ad group.
@@ -48,4 +74,5 @@ type AdGroupUpdate struct {
 	AutomatedKeywordsOptIn *bool  `json:"automatedKeywordsOptIn,omitempty"`
 	StartTime              string `json:"startTime,omitempty"`
 	EndTime                string `json:"endTime,omitempty"`
+	TargetingDimensions    *TargetingDimensions `json:"targetingDimensions,omitempty"`
 }
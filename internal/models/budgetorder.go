@@ -0,0 +1,50 @@
+package models
+
+// BudgetOrder is a budget order: an invoiced (LOC) budget that campaigns
+// draw from.
+type BudgetOrder struct {
+	ID                int64    `json:"id,omitempty"`
+	ParentOrgID       int64    `json:"parentOrgId,omitempty"`
+	Name              string   `json:"name,omitempty"`
+	Budget            *Money   `json:"budget,omitempty"`
+	StartDate         string   `json:"startDate,omitempty"`
+	EndDate           string   `json:"endDate,omitempty"`
+	OrderNumber       string   `json:"orderNumber,omitempty"`
+	ClientName        string   `json:"clientName,omitempty"`
+	PrimaryBuyerName  string   `json:"primaryBuyerName,omitempty"`
+	PrimaryBuyerEmail string   `json:"primaryBuyerEmail,omitempty"`
+	BillingEmail      string   `json:"billingEmail,omitempty"`
+	Status            string   `json:"status,omitempty"`
+	SupplySources     []string `json:"supplySources,omitempty"`
+}
+
+// BudgetOrderInfo is how the API wraps a budget order.
+type BudgetOrderInfo struct {
+	BO BudgetOrder `json:"bo"`
+}
+
+// BudgetOrderUpdate contains fields that can be updated on a budget order.
+type BudgetOrderUpdate struct {
+	Name              string `json:"name,omitempty"`
+	Budget            *Money `json:"budget,omitempty"`
+	StartDate         string `json:"startDate,omitempty"`
+	EndDate           string `json:"endDate,omitempty"`
+	OrderNumber       string `json:"orderNumber,omitempty"`
+	ClientName        string `json:"clientName,omitempty"`
+	PrimaryBuyerName  string `json:"primaryBuyerName,omitempty"`
+	PrimaryBuyerEmail string `json:"primaryBuyerEmail,omitempty"`
+	BillingEmail      string `json:"billingEmail,omitempty"`
+}
+
+// CreateBudgetOrderRequest is the create payload: the orgs the budget order
+// is for and the order itself.
+type CreateBudgetOrderRequest struct {
+	OrgIDs []int64      `json:"orgIds"`
+	BO     *BudgetOrder `json:"bo"`
+}
+
+// UpdateBudgetOrderRequest is the update payload.
+type UpdateBudgetOrderRequest struct {
+	OrgIDs []int64            `json:"orgIds"`
+	BO     *BudgetOrderUpdate `json:"bo"`
+}
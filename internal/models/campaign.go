@@ -18,10 +18,12 @@ type Campaign struct {
 	BillingEvent                       string                 `json:"billingEvent,omitempty"`
 	CountriesOrRegions                 []string               `json:"countriesOrRegions,omitempty"`
 	CountryOrRegionServingStateReasons map[string]interface{} `json:"countryOrRegionServingStateReasons,omitempty"`
+	Deleted                            bool                   `json:"deleted,omitempty"`
 	ModificationTime                   string                 `json:"modificationTime,omitempty"`
 	StartTime                          string                 `json:"startTime,omitempty"`
 	EndTime                            string                 `json:"endTime,omitempty"`
 	LOCInvoiceDetails                  *LOCInvoiceDetails     `json:"locInvoiceDetails,omitempty"`
+	BudgetOrders                       []int64                `json:"budgetOrders,omitempty"`
 }
 
 // LOCInvoiceDetails for billing.
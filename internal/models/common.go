@@ -1,6 +1,9 @@
 package models
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"strconv"
+)
 
 // Money represents a monetary amount.
 type Money struct {
@@ -8,6 +11,15 @@ type Money struct {
 	Currency string `json:"currency"`
 }
 
+// Float returns the amount as a number, or 0 when m is nil or unparsable.
+func (m *Money) Float() float64 {
+	if m == nil {
+		return 0
+	}
+	v, _ := strconv.ParseFloat(m.Amount, 64)
+	return v
+}
+
 // PageDetail contains pagination metadata from API responses.
 type PageDetail struct {
 	TotalResults int `json:"totalResults"`
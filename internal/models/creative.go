@@ -0,0 +1,39 @@
+package models
+
+// ProductPage is a custom product page of an app in App Store Connect.
+type ProductPage struct {
+	ID               string `json:"id"`
+	AdamID           int64  `json:"adamId"`
+	Name             string `json:"name"`
+	State            string `json:"state"`
+	DeepLink         string `json:"deepLink,omitempty"`
+	CreationTime     string `json:"creationTime,omitempty"`
+	ModificationTime string `json:"modificationTime,omitempty"`
+}
+
+// ProductPageLocale is the localized metadata of a custom product page.
+type ProductPageLocale struct {
+	AdamID           int64  `json:"adamId"`
+	ProductPageID    string `json:"productPageId"`
+	Language         string `json:"language"`
+	LanguageCode     string `json:"languageCode"`
+	AppName          string `json:"appName,omitempty"`
+	SubTitle         string `json:"subTitle,omitempty"`
+	PromotionalText  string `json:"promotionalText,omitempty"`
+	ShortDescription string `json:"shortDescription,omitempty"`
+}
+
+// Creative is an org-level creative that ads reference by ID. Creatives of
+// type CUSTOM_PRODUCT_PAGE point at a product page of the app.
+type Creative struct {
+	ID               int64    `json:"id,omitempty"`
+	OrgID            int64    `json:"orgId,omitempty"`
+	AdamID           int64    `json:"adamId"`
+	Name             string   `json:"name"`
+	Type             string   `json:"type"`
+	ProductPageID    string   `json:"productPageId,omitempty"`
+	State            string   `json:"state,omitempty"`
+	StateReasons     []string `json:"stateReasons,omitempty"`
+	CreationTime     string   `json:"creationTime,omitempty"`
+	ModificationTime string   `json:"modificationTime,omitempty"`
+}
@@ -1,5 +1,7 @@
 package models
 
+import "strings"
+
 // Keyword represents a targeting keyword.
 type Keyword struct {
 	ID               int64  `json:"id,omitempty"`
@@ -31,3 +33,16 @@ type KeywordUpdate struct {
 	Status    string `json:"status,omitempty"`
 	BidAmount *Money `json:"bidAmount,omitempty"`
 }
+
+// NegativeKeywordUpdate contains fields that can be updated on a negative
+// keyword.
+type NegativeKeywordUpdate struct {
+	ID     int64  `json:"id"`
+	Status string `json:"status,omitempty"` // ACTIVE or PAUSED
+}
+
+// Key is the identity of a keyword or negative keyword within an ad group or
+// campaign: its text, case- and whitespace-insensitively, and its match type.
+func Key(text, matchType string) string {
+	return strings.Join(strings.Fields(strings.ToLower(text)), " ") + "|" + strings.ToUpper(matchType)
+}
@@ -78,3 +78,36 @@ type SearchTermReportRow struct {
 	Total    *SpendRow              `json:"total,omitempty"`
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
 }
+
+// ImpressionShareReportRequest creates an impression share custom report.
+// DateRange is LAST_WEEK, LAST_2_WEEKS, LAST_4_WEEKS, or CUSTOM with
+// StartTime and EndTime.
+type ImpressionShareReportRequest struct {
+	Name        string                `json:"name"`
+	StartTime   string                `json:"startTime,omitempty"`
+	EndTime     string                `json:"endTime,omitempty"`
+	DateRange   string                `json:"dateRange"`
+	Granularity string                `json:"granularity"` // DAILY, WEEKLY
+	Selector    *CustomReportSelector `json:"selector,omitempty"`
+}
+
+// CustomReportSelector filters a custom report, e.g. by countryOrRegion or
+// adamId.
+type CustomReportSelector struct {
+	Conditions []Condition `json:"conditions"`
+}
+
+// ImpressionShareReport is a custom report. It can be downloaded from
+// DownloadURI once State is COMPLETED.
+type ImpressionShareReport struct {
+	ID               int64  `json:"id"`
+	Name             string `json:"name"`
+	StartTime        string `json:"startTime,omitempty"`
+	EndTime          string `json:"endTime,omitempty"`
+	DateRange        string `json:"dateRange,omitempty"`
+	Granularity      string `json:"granularity,omitempty"`
+	State            string `json:"state"` // QUEUED, PENDING, COMPLETED, FAILED
+	DownloadURI      string `json:"downloadUri,omitempty"`
+	CreationTime     string `json:"creationTime,omitempty"`
+	ModificationTime string `json:"modificationTime,omitempty"`
+}
@@ -0,0 +1,127 @@
+// Package negatives manages shared negative keyword lists: named sets of
+// negatives kept in a local YAML file and synced to many campaigns.
+package negatives
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/trebuhs/asa-cli/internal/config"
+	"github.com/trebuhs/asa-cli/internal/models"
+	"go.yaml.in/yaml/v3"
+)
+
+// Keyword is a negative keyword in a list. In YAML it is either a mapping
+// with text and match_type, or a plain string for an EXACT negative.
+type Keyword struct {
+	Text      string `yaml:"text"`
+	MatchType string `yaml:"match_type"`
+}
+
+// UnmarshalYAML accepts a plain string as shorthand for an EXACT negative.
+func (k *Keyword) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		k.Text = node.Value
+		return nil
+	}
+	type plain Keyword
+	return node.Decode((*plain)(k))
+}
+
+// Lists maps list names to their negative keywords.
+type Lists map[string][]Keyword
+
+// DefaultPath is the lists file used when none is given.
+func DefaultPath() string {
+	return filepath.Join(config.ConfigDir(), "negative-lists.yaml")
+}
+
+// Load reads and validates a lists file.
+func Load(path string) (Lists, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading negative lists: %w", err)
+	}
+	var lists Lists
+	if err := yaml.Unmarshal(data, &lists); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	for name, keywords := range lists {
+		for i := range keywords {
+			kw := &keywords[i]
+			kw.Text = strings.TrimSpace(kw.Text)
+			kw.MatchType = strings.ToUpper(kw.MatchType)
+			if kw.MatchType == "" {
+				kw.MatchType = "EXACT"
+			}
+			if kw.Text == "" {
+				return nil, fmt.Errorf("%s: list %q has an empty keyword", path, name)
+			}
+			if kw.MatchType != "EXACT" && kw.MatchType != "BROAD" {
+				return nil, fmt.Errorf("%s: list %q: invalid match type %q for %q (expected EXACT or BROAD)", path, name, kw.MatchType, kw.Text)
+			}
+		}
+	}
+	return lists, nil
+}
+
+// Names returns the list names in order.
+func (l Lists) Names() []string {
+	names := make([]string, 0, len(l))
+	for name := range l {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Resolve returns the union of the named lists without duplicates.
+func (l Lists) Resolve(names []string) ([]Keyword, error) {
+	seen := map[string]bool{}
+	var keywords []Keyword
+	for _, name := range names {
+		list, ok := l[name]
+		if !ok {
+			return nil, fmt.Errorf("no negative list named %q (have: %s)", name, strings.Join(l.Names(), ", "))
+		}
+		for _, kw := range list {
+			if k := models.Key(kw.Text, kw.MatchType); !seen[k] {
+				seen[k] = true
+				keywords = append(keywords, kw)
+			}
+		}
+	}
+	return keywords, nil
+}
+
+// Diff compares the wanted negatives with a campaign's existing ones. It
+// returns the negatives to create and, with remove, the existing negatives
+// that are in none of the lists. Keywords match on text, case-insensitively,
+// and match type; paused negatives count as present.
+func Diff(want []Keyword, existing []models.NegativeKeyword, remove bool) (add, del []models.NegativeKeyword) {
+	have := map[string]bool{}
+	for _, nk := range existing {
+		if !nk.Deleted {
+			have[models.Key(nk.Text, nk.MatchType)] = true
+		}
+	}
+	wanted := map[string]bool{}
+	for _, kw := range want {
+		k := models.Key(kw.Text, kw.MatchType)
+		wanted[k] = true
+		if !have[k] {
+			add = append(add, models.NegativeKeyword{Text: kw.Text, MatchType: kw.MatchType})
+		}
+	}
+	if remove {
+		for _, nk := range existing {
+			if !nk.Deleted && !wanted[models.Key(nk.Text, nk.MatchType)] {
+				del = append(del, nk)
+			}
+		}
+	}
+	return add, del
+}
@@ -0,0 +1,78 @@
+package negatives
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/trebuhs/asa-cli/internal/models"
+)
+
+func TestDiff(t *testing.T) {
+	existing := []models.NegativeKeyword{
+		{ID: 1, Text: "Free", MatchType: "EXACT", Status: "ACTIVE"},
+		{ID: 2, Text: "cheap", MatchType: "BROAD", Status: "PAUSED"},
+		{ID: 3, Text: "crack", MatchType: "EXACT", Deleted: true},
+		{ID: 4, Text: "torrent", MatchType: "EXACT", Status: "ACTIVE"},
+	}
+
+	tests := []struct {
+		name    string
+		want    []Keyword
+		remove  bool
+		wantNew []string
+		wantDel []int64
+	}{
+		{
+			name: "nothing missing",
+			want: []Keyword{{Text: "free", MatchType: "EXACT"}, {Text: "cheap", MatchType: "BROAD"}},
+		},
+		{
+			name:    "match type must match",
+			want:    []Keyword{{Text: "free", MatchType: "BROAD"}},
+			wantNew: []string{"free|BROAD"},
+		},
+		{
+			name:    "deleted negatives are not present",
+			want:    []Keyword{{Text: "crack", MatchType: "EXACT"}},
+			wantNew: []string{"crack|EXACT"},
+		},
+		{
+			name:    "without remove nothing is deleted",
+			want:    []Keyword{{Text: "free", MatchType: "EXACT"}},
+			wantDel: nil,
+		},
+		{
+			name:    "remove deletes live negatives in no list",
+			want:    []Keyword{{Text: "FREE", MatchType: "EXACT"}},
+			remove:  true,
+			wantDel: []int64{2, 4},
+		},
+		{
+			name:    "remove with additions",
+			want:    []Keyword{{Text: "free", MatchType: "EXACT"}, {Text: "cheap", MatchType: "BROAD"}, {Text: "hack", MatchType: "EXACT"}},
+			remove:  true,
+			wantNew: []string{"hack|EXACT"},
+			wantDel: []int64{4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			add, del := Diff(tt.want, existing, tt.remove)
+			var gotNew []string
+			for _, nk := range add {
+				gotNew = append(gotNew, nk.Text+"|"+nk.MatchType)
+			}
+			var gotDel []int64
+			for _, nk := range del {
+				gotDel = append(gotDel, nk.ID)
+			}
+			if !reflect.DeepEqual(gotNew, tt.wantNew) {
+				t.Errorf("add = %v, want %v", gotNew, tt.wantNew)
+			}
+			if !reflect.DeepEqual(gotDel, tt.wantDel) {
+				t.Errorf("del = %v, want %v", gotDel, tt.wantDel)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+// Package pacing projects campaign spend from budgets and daily spend.
+package pacing
+
+import (
+	"math"
+	"time"
+)
+
+// Flags.
+const (
+	OK       = "OK"
+	Under    = "UNDERSPEND"
+	Over     = "OVERSPEND"
+	Exhausts = "EXHAUSTS"
+)
+
+const day = "2006-01-02"
+
+// Input is what is known about one campaign. Amounts of 0 mean unset.
+type Input struct {
+	Budget      float64
+	DailyBudget float64
+	// Spent is the spend since the campaign started.
+	Spent float64
+	// Daily is the spend per day (YYYY-MM-DD), covering at least the month
+	// so far and the run-rate window.
+	Daily map[string]float64
+	// Today is the current day; its spend is partial.
+	Today time.Time
+	// Start is the day the campaign started, or zero if unknown.
+	Start time.Time
+	// Window is the number of complete days before Today the run rate is
+	// averaged over. Days before Start are left out.
+	Window int
+	// Tolerance is how far, as a fraction of the month's plan, the projected
+	// month spend may be off before it is flagged.
+	Tolerance float64
+}
+
+// Pace is the result for one campaign.
+type Pace struct {
+	SpentPct float64
+	// Remaining is the budget left, or -1 without a total budget.
+	Remaining float64
+	RunRate   float64
+	// Exhaustion is the day the total budget runs out at the run rate, or ""
+	// if it lasts beyond the month or there is no total budget.
+	Exhaustion string
+	// CappedDays counts days in the window that spent the daily budget.
+	CappedDays  int
+	MonthToDate float64
+	// MonthProjected is the month's spend if the run rate holds.
+	MonthProjected float64
+	// MonthPlan is the daily budget times the days in the month the campaign
+	// runs: from the first, or from its start if it started this month.
+	MonthPlan float64
+	Flag      string
+}
+
+// cappedShare is the share of the daily budget a day must spend to count as
+// capped; delivery rarely lands on the cent.
+const cappedShare = 0.95
+
+// Compute projects a campaign's spend.
+func Compute(in Input) Pace {
+	p := Pace{Remaining: -1, Flag: OK}
+	today := truncate(in.Today)
+
+	window := in.Window
+	if !in.Start.IsZero() {
+		live := int(today.Sub(truncate(in.Start)).Hours() / 24)
+		window = min(window, max(live, 0))
+	}
+	for i := 1; i <= window; i++ {
+		spend := in.Daily[today.AddDate(0, 0, -i).Format(day)]
+		p.RunRate += spend
+		if in.DailyBudget > 0 && spend >= in.DailyBudget*cappedShare {
+			p.CappedDays++
+		}
+	}
+	if window > 0 {
+		p.RunRate /= float64(window)
+	}
+
+	monthStart := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+	monthEnd := monthStart.AddDate(0, 1, -1)
+	for d := monthStart; !d.After(today); d = d.AddDate(0, 0, 1) {
+		p.MonthToDate += in.Daily[d.Format(day)]
+	}
+	daysLeft := monthEnd.Sub(today).Hours() / 24
+	p.MonthProjected = p.MonthToDate + p.RunRate*daysLeft
+	planStart := monthStart
+	if start := truncate(in.Start); start.After(planStart) {
+		planStart = start
+	}
+	planDays := math.Max(monthEnd.Sub(planStart).Hours()/24+1, 0)
+	p.MonthPlan = in.DailyBudget * planDays
+
+	if in.Budget > 0 {
+		p.SpentPct = in.Spent / in.Budget * 100
+		p.Remaining = math.Max(in.Budget-in.Spent, 0)
+		// Total budget caps the month too.
+		p.MonthProjected = math.Min(p.MonthProjected, p.MonthToDate+p.Remaining)
+		if p.Remaining == 0 {
+			p.Exhaustion = today.Format(day)
+		} else if p.RunRate > 0 {
+			if out := today.AddDate(0, 0, int(math.Ceil(p.Remaining/p.RunRate))); !out.After(monthEnd) {
+				p.Exhaustion = out.Format(day)
+			}
+		}
+	}
+
+	switch {
+	case p.Exhaustion != "":
+		p.Flag = Exhausts
+	case p.MonthPlan > 0 && p.MonthProjected > p.MonthPlan*(1+in.Tolerance):
+		p.Flag = Over
+	case p.MonthPlan > 0 && p.MonthProjected < p.MonthPlan*(1-in.Tolerance):
+		p.Flag = Under
+	}
+	return p
+}
+
+func truncate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,104 @@
+package pacing
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// spend returns the same spend for every day from start to end, inclusive.
+func spend(start, end string, amount float64) map[string]float64 {
+	daily := map[string]float64{}
+	from, _ := time.Parse(day, start)
+	to, _ := time.Parse(day, end)
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		daily[d.Format(day)] = amount
+	}
+	return daily
+}
+
+func TestCompute(t *testing.T) {
+	today := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		in             Input
+		wantFlag       string
+		wantRunRate    float64
+		wantExhaustion string
+	}{
+		{
+			name:        "on plan",
+			in:          Input{DailyBudget: 100, Daily: spend("2026-10-01", "2026-10-14", 100)},
+			wantFlag:    OK,
+			wantRunRate: 100,
+		},
+		{
+			name:        "under",
+			in:          Input{DailyBudget: 100, Daily: spend("2026-10-01", "2026-10-14", 20)},
+			wantFlag:    Under,
+			wantRunRate: 20,
+		},
+		{
+			name:        "over",
+			in:          Input{DailyBudget: 100, Daily: spend("2026-10-01", "2026-10-14", 150)},
+			wantFlag:    Over,
+			wantRunRate: 150,
+		},
+		{
+			name:           "exhausts this month",
+			in:             Input{Budget: 1000, Spent: 900, DailyBudget: 50, Daily: spend("2026-10-01", "2026-10-14", 50)},
+			wantFlag:       Exhausts,
+			wantRunRate:    50,
+			wantExhaustion: "2026-10-17",
+		},
+		{
+			name:           "already exhausted",
+			in:             Input{Budget: 1000, Spent: 1000, DailyBudget: 50, Daily: spend("2026-10-01", "2026-10-14", 50)},
+			wantFlag:       Exhausts,
+			wantRunRate:    50,
+			wantExhaustion: "2026-10-15",
+		},
+		{
+			name:        "lasts beyond the month",
+			in:          Input{Budget: 100000, Spent: 700, DailyBudget: 100, Daily: spend("2026-10-01", "2026-10-14", 100)},
+			wantFlag:    OK,
+			wantRunRate: 100,
+		},
+		{
+			name:        "run rate counts only live days",
+			in:          Input{DailyBudget: 100, Start: time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC), Daily: spend("2026-10-12", "2026-10-14", 100)},
+			wantFlag:    OK,
+			wantRunRate: 100,
+		},
+		{
+			name:        "started mid-month on plan",
+			in:          Input{DailyBudget: 100, Start: time.Date(2026, 10, 8, 0, 0, 0, 0, time.UTC), Daily: spend("2026-10-08", "2026-10-14", 100)},
+			wantFlag:    OK,
+			wantRunRate: 100,
+		},
+		{
+			name:     "started today",
+			in:       Input{DailyBudget: 100, Start: today},
+			wantFlag: Under,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.in.Today = today
+			tt.in.Window = 7
+			tt.in.Tolerance = 0.2
+			p := Compute(tt.in)
+			if p.Flag != tt.wantFlag {
+				t.Errorf("Flag = %s, want %s", p.Flag, tt.wantFlag)
+			}
+			if math.Abs(p.RunRate-tt.wantRunRate) > 1e-9 {
+				t.Errorf("RunRate = %v, want %v", p.RunRate, tt.wantRunRate)
+			}
+			if p.Exhaustion != tt.wantExhaustion {
+				t.Errorf("Exhaustion = %q, want %q", p.Exhaustion, tt.wantExhaustion)
+			}
+		})
+	}
+}
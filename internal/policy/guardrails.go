@@ -0,0 +1,354 @@
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/trebuhs/asa-cli/internal/api"
+	"github.com/trebuhs/asa-cli/internal/config"
+	"github.com/trebuhs/asa-cli/internal/models"
+	"github.com/trebuhs/asa-cli/internal/services"
+)
+
+// Guardrails is the policy every mutation sent through an api.Client passes
+// before it is sent. The user's role in the org must allow changes, and
+// amounts must pass Limits; both block outright.
+// Then the profile's relative limits apply: bid and budget increases,
+// minimum bids, the total daily budget of enabled campaigns and the number
+// of entities one command changes. Their violations block the request
+// unless OverrideReason is set.
+type Guardrails struct {
+	Limits
+	Client *api.Client
+	// OrgID is the org mutations are sent to; the user's roles in it are
+	// checked before the first one.
+	OrgID string
+	// OverrideReason lets violating requests through. The violations and the
+	// reason are recorded in the audit log.
+	OverrideReason string
+
+	changed int
+	// dailyBudgets holds the daily budget of each enabled campaign, loaded on
+	// first use and kept current as campaigns are changed.
+	dailyBudgets map[int64]float64
+	// campaignBudgets caches each campaign's daily budget for checking bids.
+	campaignBudgets map[int64]*models.Money
+
+	roleChecked bool
+	roleErr     error
+}
+
+// NewGuardrails creates guardrails for a profile's config.
+func NewGuardrails(cfg *config.Config, client *api.Client) *Guardrails {
+	return &Guardrails{Limits: Limits{Config: cfg}, Client: client}
+}
+
+// Check implements api.Policy.
+func (g *Guardrails) Check(m *api.Mutation) ([]string, error) {
+	if err := g.checkRole(); err != nil {
+		return nil, err
+	}
+	if err := g.checkLimits(m); err != nil {
+		return nil, err
+	}
+
+	n := entityCount(m.Body)
+	violations, err := g.violations(m)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.Config.CheckEntityCount(g.changed + n); err != nil {
+		violations = append(violations, err.Error())
+	}
+
+	if err := g.resolve(violations); err != nil {
+		return nil, err
+	}
+	g.changed += n
+	g.commit(m)
+	return violations, nil
+}
+
+// Expect checks, before a command sends anything, that it may change n
+// entities, so a command that is not allowed or over the limit is refused
+// instead of stopping part-way through.
+func (g *Guardrails) Expect(n int) error {
+	if err := g.checkRole(); err != nil {
+		return err
+	}
+	if err := g.Config.CheckEntityCount(g.changed + n); err != nil {
+		return g.resolve([]string{err.Error()})
+	}
+	return nil
+}
+
+// resolve blocks on violations unless they are overridden, in which case
+// they are reported as warnings.
+func (g *Guardrails) resolve(violations []string) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	if g.OverrideReason == "" {
+		return fmt.Errorf("blocked by guardrails:\n  - %s\nPass --override-reason \"...\" to proceed anyway; the reason is recorded in the audit log",
+			strings.Join(violations, "\n  - "))
+	}
+	for _, v := range violations {
+		fmt.Fprintf(os.Stderr, "Warning: guardrail overridden (%s): %s\n", g.OverrideReason, v)
+	}
+	return nil
+}
+
+// checkLimits runs Limits over the amounts in a request body.
+func (g *Guardrails) checkLimits(m *api.Mutation) error {
+	switch body := m.Body.(type) {
+	case *models.Campaign:
+		return g.Limits.Campaign(body)
+	case *models.UpdateCampaignRequest:
+		if body.Campaign != nil {
+			return g.Limits.CampaignUpdate(body.Campaign)
+		}
+	case *models.CreateBudgetOrderRequest:
+		if body.BO != nil {
+			return g.Limits.BudgetOrder(body.BO.Budget)
+		}
+	case *models.UpdateBudgetOrderRequest:
+		if body.BO != nil {
+			return g.Limits.BudgetOrder(body.BO.Budget)
+		}
+	case *models.AdGroup:
+		return g.Limits.AdGroup(body, g.campaignDailyBudget(m.Path))
+	case *models.AdGroupUpdate:
+		var daily *models.Money
+		if body.DefaultBidAmount != nil || body.CpaGoal != nil {
+			daily = g.campaignDailyBudget(m.Path)
+		}
+		return g.Limits.AdGroupUpdate(body, daily)
+	case []models.Keyword:
+		for _, kw := range body {
+			if kw.BidAmount != nil {
+				if err := g.Limits.Keyword(kw.BidAmount, g.campaignDailyBudget(m.Path)); err != nil {
+					return fmt.Errorf("keyword %q: %w", kw.Text, err)
+				}
+			}
+		}
+	case []models.KeywordUpdate:
+		for _, u := range body {
+			if u.BidAmount != nil {
+				if err := g.Limits.Keyword(u.BidAmount, g.campaignDailyBudget(m.Path)); err != nil {
+					return fmt.Errorf("keyword %d: %w", u.ID, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+var campaignPathID = regexp.MustCompile(`^/campaigns/(\d+)/`)
+
+// campaignDailyBudget returns the daily budget of the campaign a request path
+// is scoped to, or nil if it cannot be determined.
+func (g *Guardrails) campaignDailyBudget(path string) *models.Money {
+	match := campaignPathID.FindStringSubmatch(path)
+	if match == nil {
+		return nil
+	}
+	id, _ := strconv.ParseInt(match[1], 10, 64)
+	if daily, ok := g.campaignBudgets[id]; ok {
+		return daily
+	}
+	if g.campaignBudgets == nil {
+		g.campaignBudgets = map[int64]*models.Money{}
+	}
+	c, err := services.NewCampaignService(g.Client).Get(id)
+	if err != nil {
+		return nil
+	}
+	g.campaignBudgets[id] = c.DailyBudgetAmount
+	return c.DailyBudgetAmount
+}
+
+func (g *Guardrails) violations(m *api.Mutation) ([]string, error) {
+	var out []string
+	add := func(err error) {
+		if err != nil {
+			out = append(out, err.Error())
+		}
+	}
+
+	switch body := m.Body.(type) {
+	case *models.Campaign:
+		if enabled(body.Status) && body.DailyBudgetAmount != nil {
+			total, err := g.totalDailyBudget(0, body.DailyBudgetAmount.Float())
+			if err != nil {
+				return nil, err
+			}
+			add(g.Config.CheckTotalDailyBudget(total))
+		}
+
+	case *models.UpdateCampaignRequest:
+		if body.Campaign == nil {
+			break
+		}
+		var before models.Campaign
+		_ = json.Unmarshal(m.Before, &before)
+		update := body.Campaign
+
+		if update.DailyBudgetAmount != nil && before.DailyBudgetAmount != nil {
+			add(g.Config.CheckBudgetIncrease(before.DailyBudgetAmount.Float(), update.DailyBudgetAmount.Float()))
+		}
+		if before.ID != 0 && (update.DailyBudgetAmount != nil || update.Status != "") {
+			after := before
+			if update.Status != "" {
+				after.Status = update.Status
+			}
+			if update.DailyBudgetAmount != nil {
+				after.DailyBudgetAmount = update.DailyBudgetAmount
+			}
+			if enabled(after.Status) && contribution(after) > contribution(before) {
+				total, err := g.totalDailyBudget(before.ID, contribution(after))
+				if err != nil {
+					return nil, err
+				}
+				add(g.Config.CheckTotalDailyBudget(total))
+			}
+		}
+
+	case *models.AdGroup:
+		if body.DefaultBidAmount != nil {
+			add(g.Config.CheckMinBid(body.DefaultBidAmount.Float()))
+		}
+
+	case *models.AdGroupUpdate:
+		if body.DefaultBidAmount != nil {
+			add(g.Config.CheckMinBid(body.DefaultBidAmount.Float()))
+			var before models.AdGroup
+			if json.Unmarshal(m.Before, &before) == nil && before.DefaultBidAmount != nil {
+				add(g.Config.CheckBidIncrease(before.DefaultBidAmount.Float(), body.DefaultBidAmount.Float()))
+			}
+		}
+
+	case []models.Keyword:
+		for _, kw := range body {
+			if kw.BidAmount != nil {
+				add(prefix(kw.Text, g.Config.CheckMinBid(kw.BidAmount.Float())))
+			}
+		}
+
+	case []models.KeywordUpdate:
+		var before []models.Keyword
+		_ = json.Unmarshal(m.Before, &before)
+		old := map[int64]models.Keyword{}
+		for _, kw := range before {
+			old[kw.ID] = kw
+		}
+		for _, u := range body {
+			if u.BidAmount == nil {
+				continue
+			}
+			label := strconv.FormatInt(u.ID, 10)
+			prev, ok := old[u.ID]
+			if ok {
+				label = prev.Text
+			}
+			add(prefix(label, g.Config.CheckMinBid(u.BidAmount.Float())))
+			if ok && prev.BidAmount != nil {
+				add(prefix(label, g.Config.CheckBidIncrease(prev.BidAmount.Float(), u.BidAmount.Float())))
+			}
+		}
+	}
+	return out, nil
+}
+
+// commit updates the cached campaign budgets after a campaign change is let
+// through, so later checks in the same command see it.
+func (g *Guardrails) commit(m *api.Mutation) {
+	if g.dailyBudgets == nil {
+		return
+	}
+	if created, ok := m.Body.(*models.Campaign); ok {
+		// The new campaign's ID is not known yet; key it below zero.
+		g.dailyBudgets[-int64(len(g.dailyBudgets)+1)] = contribution(*created)
+		return
+	}
+	body, ok := m.Body.(*models.UpdateCampaignRequest)
+	if !ok || body.Campaign == nil {
+		return
+	}
+	var before models.Campaign
+	if json.Unmarshal(m.Before, &before) != nil || before.ID == 0 {
+		return
+	}
+	if body.Campaign.Status != "" {
+		before.Status = body.Campaign.Status
+	}
+	if body.Campaign.DailyBudgetAmount != nil {
+		before.DailyBudgetAmount = body.Campaign.DailyBudgetAmount
+	}
+	g.dailyBudgets[before.ID] = contribution(before)
+}
+
+// totalDailyBudget returns the summed daily budget of enabled campaigns if
+// campaign id (0 for a new campaign) had the given daily budget.
+func (g *Guardrails) totalDailyBudget(id int64, budget float64) (float64, error) {
+	if g.Config.MaxTotalDailyBudget <= 0 {
+		return 0, nil
+	}
+	if g.dailyBudgets == nil {
+		campaigns, err := services.NewCampaignService(g.Client).FindAll(models.NewSelector(1000, 0))
+		if err != nil {
+			return 0, fmt.Errorf("fetching campaigns for max_total_daily_budget: %w", err)
+		}
+		g.dailyBudgets = map[int64]float64{}
+		for _, c := range campaigns {
+			if !c.Deleted {
+				g.dailyBudgets[c.ID] = contribution(c)
+			}
+		}
+	}
+
+	total := budget
+	for cid, b := range g.dailyBudgets {
+		if cid != id {
+			total += b
+		}
+	}
+	return total, nil
+}
+
+// contribution is what a campaign adds to the total enabled daily budget.
+func contribution(c models.Campaign) float64 {
+	if !enabled(c.Status) || c.DailyBudgetAmount == nil {
+		return 0
+	}
+	return c.DailyBudgetAmount.Float()
+}
+
+// enabled reports whether a status serves. New campaigns default to ENABLED.
+func enabled(status string) bool {
+	return status == "" || strings.EqualFold(status, "ENABLED")
+}
+
+func prefix(label string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("%s: %w", label, err)
+}
+
+// entityCount is the number of entities a request body changes: one per
+// element of a bulk body, otherwise one.
+func entityCount(body interface{}) int {
+	if body == nil {
+		return 1
+	}
+	v := reflect.ValueOf(body)
+	if v.Kind() == reflect.Slice {
+		return v.Len()
+	}
+	return 1
+}
@@ -0,0 +1,108 @@
+package policy
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/trebuhs/asa-cli/internal/api"
+	"github.com/trebuhs/asa-cli/internal/config"
+	"github.com/trebuhs/asa-cli/internal/models"
+)
+
+func TestGuardrailsCheck(t *testing.T) {
+	cfg := &config.Config{MaxBidIncreasePct: 50, MaxBudgetIncreasePct: 100, MinBid: 0.5, MaxEntitiesPerCommand: 2}
+	before := func(v interface{}) json.RawMessage {
+		data, _ := json.Marshal(v)
+		return data
+	}
+
+	tests := []struct {
+		name           string
+		m              api.Mutation
+		override       string
+		wantViolations int
+		wantErr        string
+	}{
+		{
+			name: "bid within limits",
+			m: api.Mutation{
+				Path:   "/campaigns/1/adgroups/2/targetingkeywords/bulk",
+				Body:   []models.KeywordUpdate{{ID: 3, BidAmount: usd("1.20")}},
+				Before: before([]models.Keyword{{ID: 3, Text: "habit tracker", BidAmount: usd("1.00")}}),
+			},
+		},
+		{
+			name: "bid increase blocks",
+			m: api.Mutation{
+				Path:   "/campaigns/1/adgroups/2/targetingkeywords/bulk",
+				Body:   []models.KeywordUpdate{{ID: 3, BidAmount: usd("2.00")}},
+				Before: before([]models.Keyword{{ID: 3, Text: "habit tracker", BidAmount: usd("1.00")}}),
+			},
+			wantErr: "habit tracker: bid 1.00 -> 2.00 is a 100% increase",
+		},
+		{
+			name: "bid increase with override reason",
+			m: api.Mutation{
+				Path:   "/campaigns/1/adgroups/2/targetingkeywords/bulk",
+				Body:   []models.KeywordUpdate{{ID: 3, BidAmount: usd("2.00")}},
+				Before: before([]models.Keyword{{ID: 3, Text: "habit tracker", BidAmount: usd("1.00")}}),
+			},
+			override:       "launch week",
+			wantViolations: 1,
+		},
+		{
+			name:    "bid below min_bid",
+			m:       api.Mutation{Path: "/campaigns/1/adgroups", Body: &models.AdGroup{DefaultBidAmount: usd("0.10")}},
+			wantErr: "bid 0.10 is below min_bid",
+		},
+		{
+			name:     "limits block even with override",
+			m:        api.Mutation{Path: "/campaigns/1/adgroups/2/targetingkeywords/bulk", Body: []models.Keyword{{Text: "habits", BidAmount: usd("30")}}},
+			override: "launch week",
+			wantErr:  `keyword "habits": bid 30 exceeds the campaign daily budget 20`,
+		},
+		{
+			name: "daily budget increase",
+			m: api.Mutation{
+				Path:   "/campaigns/1",
+				Body:   &models.UpdateCampaignRequest{Campaign: &models.CampaignUpdate{DailyBudgetAmount: usd("50")}},
+				Before: before(models.Campaign{DailyBudgetAmount: usd("20")}),
+			},
+			wantErr: "daily budget 20.00 -> 50.00 is a 150% increase",
+		},
+		{
+			name:    "budget order amounts are checked",
+			m:       api.Mutation{Path: "/budgetorders", Body: &models.CreateBudgetOrderRequest{BO: &models.BudgetOrder{Budget: usd("1,000")}}},
+			wantErr: "budget order budget",
+		},
+		{
+			name:    "entity count",
+			m:       api.Mutation{Path: "/campaigns/1/adgroups/2/targetingkeywords/bulk", Body: []models.Keyword{{Text: "a"}, {Text: "b"}, {Text: "c"}}},
+			wantErr: "command would change 3 entities, above max_entities_per_command (2)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGuardrails(cfg, nil)
+			g.OverrideReason = tt.override
+			g.roleChecked = true
+			g.campaignBudgets = map[int64]*models.Money{1: usd("20")}
+
+			violations, err := g.Check(&tt.m)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Check() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Check: %v", err)
+			}
+			if len(violations) != tt.wantViolations {
+				t.Errorf("violations = %v, want %d", violations, tt.wantViolations)
+			}
+		})
+	}
+}
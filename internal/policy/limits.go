@@ -0,0 +1,182 @@
+package policy
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/trebuhs/asa-cli/internal/config"
+	"github.com/trebuhs/asa-cli/internal/models"
+)
+
+// Limits are the absolute checks on every amount sent to the API: that it is
+// a plain positive decimal in a valid currency, the max_* caps from config,
+// and that bids and CPA goals fit within the campaign's daily budget. Force
+// skips the configured caps; the sanity checks always apply.
+type Limits struct {
+	Config *config.Config
+	Force  bool
+}
+
+var amountPattern = regexp.MustCompile(`^\d+(\.\d{1,2})?$`)
+
+// Amount checks that a money amount is a positive decimal with a dot as the
+// decimal separator and at most two decimals.
+func (l Limits) Amount(field, amount string) error {
+	if strings.Contains(amount, ",") {
+		return fmt.Errorf("%s %q: use a dot as the decimal separator and no thousands separator (e.g. 1.50)", field, amount)
+	}
+	if !amountPattern.MatchString(amount) {
+		return fmt.Errorf("%s %q is not a valid amount (expected e.g. 1.50)", field, amount)
+	}
+	if v, _ := strconv.ParseFloat(amount, 64); v <= 0 {
+		return fmt.Errorf("%s must be greater than zero", field)
+	}
+	return nil
+}
+
+// Budget checks a campaign total budget.
+func (l Limits) Budget(amount string) error {
+	return l.check("budget", amount, l.Config.CheckBudget)
+}
+
+// DailyBudget checks a campaign daily budget.
+func (l Limits) DailyBudget(amount string) error {
+	return l.check("daily budget", amount, l.Config.CheckDailyBudget)
+}
+
+// Bid checks a keyword bid or ad group default bid.
+func (l Limits) Bid(amount string) error {
+	return l.check("bid", amount, l.Config.CheckBid)
+}
+
+// CpaGoal checks an ad group CPA goal.
+func (l Limits) CpaGoal(amount string) error {
+	return l.check("CPA goal", amount, l.Config.CheckCpaGoal)
+}
+
+func (l Limits) check(field, amount string, limit func(float64) error) error {
+	if err := l.Amount(field, amount); err != nil {
+		return err
+	}
+	if l.Force || l.Config == nil {
+		return nil
+	}
+	v, _ := strconv.ParseFloat(amount, 64)
+	return limit(v)
+}
+
+// BudgetOrder checks a budget order's budget. There is no configured cap.
+func (l Limits) BudgetOrder(budget *models.Money) error {
+	return l.money(func(amount string) error { return l.Amount("budget order budget", amount) }, budget)
+}
+
+// WithinDailyBudget checks that a bid or CPA goal does not exceed the daily
+// budget of its campaign, which usually means a misplaced decimal point.
+// Either amount may be empty when unknown.
+func (l Limits) WithinDailyBudget(field, amount, dailyBudget string) error {
+	v, err1 := strconv.ParseFloat(amount, 64)
+	daily, err2 := strconv.ParseFloat(dailyBudget, 64)
+	if err1 != nil || err2 != nil || daily <= 0 {
+		return nil
+	}
+	if v > daily {
+		return fmt.Errorf("%s %s exceeds the campaign daily budget %s", field, amount, dailyBudget)
+	}
+	return nil
+}
+
+// Campaign checks the amounts of a new campaign.
+func (l Limits) Campaign(c *models.Campaign) error {
+	if err := l.money(l.Budget, c.BudgetAmount); err != nil {
+		return err
+	}
+	if err := l.money(l.DailyBudget, c.DailyBudgetAmount); err != nil {
+		return err
+	}
+	if c.BudgetAmount != nil && c.DailyBudgetAmount != nil {
+		if c.DailyBudgetAmount.Float() > c.BudgetAmount.Float() {
+			return fmt.Errorf("daily budget %s exceeds the total budget %s", c.DailyBudgetAmount.Amount, c.BudgetAmount.Amount)
+		}
+	}
+	return sameCurrency(c.BudgetAmount, c.DailyBudgetAmount)
+}
+
+// CampaignUpdate checks the amounts of a campaign update.
+func (l Limits) CampaignUpdate(u *models.CampaignUpdate) error {
+	if err := l.money(l.Budget, u.BudgetAmount); err != nil {
+		return err
+	}
+	if err := l.money(l.DailyBudget, u.DailyBudgetAmount); err != nil {
+		return err
+	}
+	return sameCurrency(u.BudgetAmount, u.DailyBudgetAmount)
+}
+
+// AdGroup checks the default bid and CPA goal of a new ad group against its
+// campaign's daily budget, if known.
+func (l Limits) AdGroup(ag *models.AdGroup, daily *models.Money) error {
+	return l.bids(ag.DefaultBidAmount, ag.CpaGoal, daily)
+}
+
+// AdGroupUpdate checks the default bid and CPA goal of an ad group update.
+func (l Limits) AdGroupUpdate(u *models.AdGroupUpdate, daily *models.Money) error {
+	return l.bids(u.DefaultBidAmount, u.CpaGoal, daily)
+}
+
+// Keyword checks a keyword bid against its campaign's daily budget, if known.
+func (l Limits) Keyword(bid *models.Money, daily *models.Money) error {
+	return l.bids(bid, nil, daily)
+}
+
+func (l Limits) bids(bid, cpaGoal, daily *models.Money) error {
+	if err := l.money(l.Bid, bid); err != nil {
+		return err
+	}
+	if err := l.money(l.CpaGoal, cpaGoal); err != nil {
+		return err
+	}
+	if daily != nil {
+		if bid != nil {
+			if err := l.WithinDailyBudget("bid", bid.Amount, daily.Amount); err != nil {
+				return err
+			}
+		}
+		if cpaGoal != nil {
+			if err := l.WithinDailyBudget("CPA goal", cpaGoal.Amount, daily.Amount); err != nil {
+				return err
+			}
+		}
+	}
+	return sameCurrency(bid, cpaGoal, daily)
+}
+
+// money runs an amount check on m, if set, and validates its currency code.
+func (l Limits) money(check func(string) error, m *models.Money) error {
+	if m == nil {
+		return nil
+	}
+	if err := check(m.Amount); err != nil {
+		return err
+	}
+	if len(m.Currency) != 3 || strings.ToUpper(m.Currency) != m.Currency {
+		return fmt.Errorf("invalid currency %q for amount %s (expected an ISO code such as USD)", m.Currency, m.Amount)
+	}
+	return nil
+}
+
+// sameCurrency checks that all set amounts use one currency.
+func sameCurrency(amounts ...*models.Money) error {
+	currency := ""
+	for _, m := range amounts {
+		if m == nil {
+			continue
+		}
+		if currency != "" && m.Currency != currency {
+			return fmt.Errorf("mixed currencies %s and %s in one request", currency, m.Currency)
+		}
+		currency = m.Currency
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package policy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/trebuhs/asa-cli/internal/config"
+	"github.com/trebuhs/asa-cli/internal/models"
+)
+
+func usd(amount string) *models.Money {
+	return &models.Money{Amount: amount, Currency: "USD"}
+}
+
+func TestLimits(t *testing.T) {
+	cfg := &config.Config{MaxBid: 10, MaxDailyBudget: 500, MaxBudget: 10000, MaxCpaGoal: 50}
+
+	tests := []struct {
+		name    string
+		force   bool
+		check   func(l Limits) error
+		wantErr string
+	}{
+		{
+			name:  "valid amount",
+			check: func(l Limits) error { return l.Amount("bid", "1.50") },
+		},
+		{
+			name:    "comma decimal separator",
+			check:   func(l Limits) error { return l.Amount("bid", "1,50") },
+			wantErr: "use a dot as the decimal separator",
+		},
+		{
+			name:    "three decimals",
+			check:   func(l Limits) error { return l.Amount("bid", "1.505") },
+			wantErr: "is not a valid amount",
+		},
+		{
+			name:    "zero",
+			check:   func(l Limits) error { return l.Amount("bid", "0") },
+			wantErr: "must be greater than zero",
+		},
+		{
+			name:    "bid over max_bid",
+			check:   func(l Limits) error { return l.Bid("12") },
+			wantErr: "exceeds configured max_bid",
+		},
+		{
+			name:  "force skips max_bid",
+			force: true,
+			check: func(l Limits) error { return l.Bid("12") },
+		},
+		{
+			name:    "force keeps sanity checks",
+			force:   true,
+			check:   func(l Limits) error { return l.Bid("-1") },
+			wantErr: "is not a valid amount",
+		},
+		{
+			name: "daily budget over total budget",
+			check: func(l Limits) error {
+				return l.Campaign(&models.Campaign{BudgetAmount: usd("100"), DailyBudgetAmount: usd("200")})
+			},
+			wantErr: "daily budget 200 exceeds the total budget 100",
+		},
+		{
+			name: "mixed currencies",
+			check: func(l Limits) error {
+				return l.Campaign(&models.Campaign{BudgetAmount: usd("1000"), DailyBudgetAmount: &models.Money{Amount: "50", Currency: "EUR"}})
+			},
+			wantErr: "mixed currencies USD and EUR",
+		},
+		{
+			name: "invalid currency",
+			check: func(l Limits) error {
+				return l.CampaignUpdate(&models.CampaignUpdate{DailyBudgetAmount: &models.Money{Amount: "50", Currency: "usd"}})
+			},
+			wantErr: `invalid currency "usd"`,
+		},
+		{
+			name:    "bid above the daily budget",
+			check:   func(l Limits) error { return l.Keyword(usd("5"), usd("4")) },
+			wantErr: "bid 5 exceeds the campaign daily budget 4",
+		},
+		{
+			name:    "CPA goal above the daily budget",
+			check:   func(l Limits) error { return l.AdGroup(&models.AdGroup{CpaGoal: usd("45")}, usd("40")) },
+			wantErr: "CPA goal 45 exceeds the campaign daily budget 40",
+		},
+		{
+			name:  "bid with unknown daily budget",
+			check: func(l Limits) error { return l.AdGroupUpdate(&models.AdGroupUpdate{DefaultBidAmount: usd("5")}, nil) },
+		},
+		{
+			name:  "budget order without a cap",
+			check: func(l Limits) error { return l.BudgetOrder(usd("250000")) },
+		},
+		{
+			name:    "budget order with a comma",
+			check:   func(l Limits) error { return l.BudgetOrder(usd("250,000")) },
+			wantErr: "budget order budget",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.check(Limits{Config: cfg, Force: tt.force})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("err = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("err = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package policy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/trebuhs/asa-cli/internal/models"
+	"github.com/trebuhs/asa-cli/internal/services"
+)
+
+// CanWrite reports whether a set of Search Ads roles allows changes. Roles
+// such as "API Account Read Only" and "Limited Access API Read Only" do not;
+// "Limited Access API Read & Write" does, for the campaigns it is granted.
+// No roles allow nothing; checkRole reports that case separately.
+func CanWrite(roles []string) bool {
+	for _, r := range roles {
+		if !strings.Contains(strings.ToLower(r), "read only") {
+			return true
+		}
+	}
+	return false
+}
+
+// checkRole verifies, once per command, that the user's roles in the target
+// org allow changes, so a read-only user fails before any request is sent.
+func (g *Guardrails) checkRole() error {
+	if g.roleChecked {
+		return g.roleErr
+	}
+	g.roleChecked = true
+
+	acls, err := services.NewACLService(g.Client).GetACLs()
+	if err != nil {
+		g.roleErr = fmt.Errorf("checking your roles before making changes: %w", err)
+		return g.roleErr
+	}
+
+	var acl *models.UserACL
+	for i := range acls {
+		if g.OrgID == "" || strconv.FormatInt(acls[i].OrgID, 10) == g.OrgID {
+			acl = &acls[i]
+			break
+		}
+	}
+	switch {
+	case acl == nil:
+		g.roleErr = fmt.Errorf("org %s is not among the organizations you can access (see 'asa-cli whoami')", g.OrgID)
+	case len(acl.RoleNames) == 0:
+		g.roleErr = fmt.Errorf("you have no roles in %s (%d), so changes are not allowed; ask an account admin to assign you a read & write role",
+			acl.OrgName, acl.OrgID)
+	case !CanWrite(acl.RoleNames):
+		g.roleErr = fmt.Errorf("your role in %s (%d) is %s, which cannot make changes; ask an account admin for a read & write role",
+			acl.OrgName, acl.OrgID, strings.Join(acl.RoleNames, ", "))
+	}
+	return g.roleErr
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/trebuhs/asa-cli/internal/api"
+	"github.com/trebuhs/asa-cli/internal/models"
+)
+
+type AdService struct {
+	Client *api.Client
+}
+
+func NewAdService(client *api.Client) *AdService {
+	return &AdService{Client: client}
+}
+
+func (s *AdService) List(campaignID, adGroupID int64, limit, offset int) ([]models.Ad, *models.PageDetail, error) {
+	path := fmt.Sprintf("/campaigns/%d/adgroups/%d/ads?limit=%d&offset=%d", campaignID, adGroupID, limit, offset)
+	var ads []models.Ad
+	page, err := s.Client.Get(path, &ads)
+	return ads, page, err
+}
+
+func (s *AdService) Get(campaignID, adGroupID, adID int64) (*models.Ad, error) {
+	var ad models.Ad
+	_, err := s.Client.Get(fmt.Sprintf("/campaigns/%d/adgroups/%d/ads/%d", campaignID, adGroupID, adID), &ad)
+	return &ad, err
+}
+
+// Find searches ads across all ad groups of a campaign.
+func (s *AdService) Find(campaignID int64, selector models.Selector) ([]models.Ad, *models.PageDetail, error) {
+	var ads []models.Ad
+	page, err := s.Client.Post(fmt.Sprintf("/campaigns/%d/ads/find", campaignID), &selector, &ads)
+	return ads, page, err
+}
+
+func (s *AdService) FindAll(campaignID int64, selector models.Selector) ([]models.Ad, error) {
+	return api.PaginatedFetcher[models.Ad](s.Client, fmt.Sprintf("/campaigns/%d/ads/find", campaignID), selector)
+}
+
+func (s *AdService) Create(campaignID, adGroupID int64, ad *models.Ad) (*models.Ad, error) {
+	var created models.Ad
+	_, err := s.Client.Post(fmt.Sprintf("/campaigns/%d/adgroups/%d/ads", campaignID, adGroupID), ad, &created)
+	return &created, err
+}
+
+func (s *AdService) Update(campaignID, adGroupID, adID int64, update *models.AdUpdate) (*models.Ad, error) {
+	var updated models.Ad
+	_, err := s.Client.Put(fmt.Sprintf("/campaigns/%d/adgroups/%d/ads/%d", campaignID, adGroupID, adID), update, &updated)
+	return &updated, err
+}
+
+func (s *AdService) Delete(campaignID, adGroupID, adID int64) error {
+	return s.Client.Delete(fmt.Sprintf("/campaigns/%d/adgroups/%d/ads/%d", campaignID, adGroupID, adID))
+}
@@ -37,3 +37,22 @@ func (s *AppService) SearchGeo(query string, limit, offset int, entity string, c
 	page, err := s.Client.Get(path, &geos)
 	return geos, page, err
 }
+
+func (s *AppService) ListProductPages(adamID int64, limit, offset int) ([]models.ProductPage, *models.PageDetail, error) {
+	path := fmt.Sprintf("/apps/%d/product-pages?limit=%d&offset=%d", adamID, limit, offset)
+	var pages []models.ProductPage
+	page, err := s.Client.Get(path, &pages)
+	return pages, page, err
+}
+
+func (s *AppService) GetProductPage(adamID int64, productPageID string) (*models.ProductPage, error) {
+	var page models.ProductPage
+	_, err := s.Client.Get(fmt.Sprintf("/apps/%d/product-pages/%s", adamID, url.PathEscape(productPageID)), &page)
+	return &page, err
+}
+
+func (s *AppService) GetProductPageLocales(adamID int64, productPageID string) ([]models.ProductPageLocale, error) {
+	var locales []models.ProductPageLocale
+	_, err := s.Client.Get(fmt.Sprintf("/apps/%d/product-pages/%s/locale-details", adamID, url.PathEscape(productPageID)), &locales)
+	return locales, err
+}
@@ -0,0 +1,47 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/trebuhs/asa-cli/internal/api"
+	"github.com/trebuhs/asa-cli/internal/models"
+)
+
+type BudgetOrderService struct {
+	Client *api.Client
+}
+
+func NewBudgetOrderService(client *api.Client) *BudgetOrderService {
+	return &BudgetOrderService{Client: client}
+}
+
+func (s *BudgetOrderService) List(limit, offset int) ([]models.BudgetOrder, *models.PageDetail, error) {
+	path := fmt.Sprintf("/budgetorders?limit=%d&offset=%d", limit, offset)
+	var infos []models.BudgetOrderInfo
+	page, err := s.Client.Get(path, &infos)
+	orders := make([]models.BudgetOrder, len(infos))
+	for i, info := range infos {
+		orders[i] = info.BO
+	}
+	return orders, page, err
+}
+
+func (s *BudgetOrderService) Get(id int64) (*models.BudgetOrder, error) {
+	var info models.BudgetOrderInfo
+	_, err := s.Client.Get(fmt.Sprintf("/budgetorders/%d", id), &info)
+	return &info.BO, err
+}
+
+func (s *BudgetOrderService) Create(orgID int64, order *models.BudgetOrder) (*models.BudgetOrder, error) {
+	var info models.BudgetOrderInfo
+	req := &models.CreateBudgetOrderRequest{OrgIDs: []int64{orgID}, BO: order}
+	_, err := s.Client.Post("/budgetorders", req, &info)
+	return &info.BO, err
+}
+
+func (s *BudgetOrderService) Update(orgID, id int64, update *models.BudgetOrderUpdate) (*models.BudgetOrder, error) {
+	var info models.BudgetOrderInfo
+	req := &models.UpdateBudgetOrderRequest{OrgIDs: []int64{orgID}, BO: update}
+	_, err := s.Client.Put(fmt.Sprintf("/budgetorders/%d", id), req, &info)
+	return &info.BO, err
+}
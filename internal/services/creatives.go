@@ -0,0 +1,45 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/trebuhs/asa-cli/internal/api"
+	"github.com/trebuhs/asa-cli/internal/models"
+)
+
+type CreativeService struct {
+	Client *api.Client
+}
+
+func NewCreativeService(client *api.Client) *CreativeService {
+	return &CreativeService{Client: client}
+}
+
+func (s *CreativeService) List(limit, offset int) ([]models.Creative, *models.PageDetail, error) {
+	path := fmt.Sprintf("/creatives?limit=%d&offset=%d", limit, offset)
+	var creatives []models.Creative
+	page, err := s.Client.Get(path, &creatives)
+	return creatives, page, err
+}
+
+func (s *CreativeService) Get(creativeID int64) (*models.Creative, error) {
+	var creative models.Creative
+	_, err := s.Client.Get(fmt.Sprintf("/creatives/%d", creativeID), &creative)
+	return &creative, err
+}
+
+func (s *CreativeService) Find(selector models.Selector) ([]models.Creative, *models.PageDetail, error) {
+	var creatives []models.Creative
+	page, err := s.Client.Post("/creatives/find", &selector, &creatives)
+	return creatives, page, err
+}
+
+func (s *CreativeService) FindAll(selector models.Selector) ([]models.Creative, error) {
+	return api.PaginatedFetcher[models.Creative](s.Client, "/creatives/find", selector)
+}
+
+func (s *CreativeService) Create(creative *models.Creative) (*models.Creative, error) {
+	var created models.Creative
+	_, err := s.Client.Post("/creatives", creative, &created)
+	return &created, err
+}
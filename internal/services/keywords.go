@@ -7,6 +7,23 @@ import (
 	"github.com/trebuhs/asa-cli/internal/models"
 )
 
+// KeywordBulkLimit is the maximum number of keywords sent in a single bulk request.
+const KeywordBulkLimit = 1000
+
+// Chunk splits items into consecutive batches of at most size elements, for
+// bulk requests capped at KeywordBulkLimit.
+func Chunk[T any](items []T, size int) [][]T {
+	var out [][]T
+	for start := 0; start < len(items); start += size {
+		end := start + size
+		if end > len(items) {
+			end = len(items)
+		}
+		out = append(out, items[start:end])
+	}
+	return out
+}
+
 type KeywordService struct {
 	Client *api.Client
 }
@@ -79,12 +96,22 @@ func (s *KeywordService) FindCampaignNegativeKeywords(campaignID int64, selector
 	return keywords, page, err
 }
 
+func (s *KeywordService) FindAllCampaignNegativeKeywords(campaignID int64, selector models.Selector) ([]models.NegativeKeyword, error) {
+	return api.PaginatedFetcher[models.NegativeKeyword](s.Client, fmt.Sprintf("/campaigns/%d/negativekeywords/find", campaignID), selector)
+}
+
 func (s *KeywordService) CreateCampaignNegativeKeywords(campaignID int64, keywords []models.NegativeKeyword) ([]models.NegativeKeyword, error) {
 	var created []models.NegativeKeyword
 	_, err := s.Client.Post(fmt.Sprintf("/campaigns/%d/negativekeywords/bulk", campaignID), keywords, &created)
 	return created, err
 }
 
+func (s *KeywordService) UpdateCampaignNegativeKeywords(campaignID int64, updates []models.NegativeKeywordUpdate) ([]models.NegativeKeyword, error) {
+	var updated []models.NegativeKeyword
+	_, err := s.Client.Put(fmt.Sprintf("/campaigns/%d/negativekeywords/bulk", campaignID), updates, &updated)
+	return updated, err
+}
+
 func (s *KeywordService) DeleteCampaignNegativeKeywords(campaignID int64, keywordIDs []int64) error {
 	path := fmt.Sprintf("/campaigns/%d/negativekeywords/delete/bulk", campaignID)
 	_, err := s.Client.Post(path, keywordIDs, nil)
@@ -112,12 +139,22 @@ func (s *KeywordService) FindAdGroupNegativeKeywords(campaignID, adGroupID int64
 	return keywords, page, err
 }
 
+func (s *KeywordService) FindAllAdGroupNegativeKeywords(campaignID, adGroupID int64, selector models.Selector) ([]models.NegativeKeyword, error) {
+	return api.PaginatedFetcher[models.NegativeKeyword](s.Client, fmt.Sprintf("/campaigns/%d/adgroups/%d/negativekeywords/find", campaignID, adGroupID), selector)
+}
+
 func (s *KeywordService) CreateAdGroupNegativeKeywords(campaignID, adGroupID int64, keywords []models.NegativeKeyword) ([]models.NegativeKeyword, error) {
 	var created []models.NegativeKeyword
 	_, err := s.Client.Post(fmt.Sprintf("/campaigns/%d/adgroups/%d/negativekeywords/bulk", campaignID, adGroupID), keywords, &created)
 	return created, err
 }
 
+func (s *KeywordService) UpdateAdGroupNegativeKeywords(campaignID, adGroupID int64, updates []models.NegativeKeywordUpdate) ([]models.NegativeKeyword, error) {
+	var updated []models.NegativeKeyword
+	_, err := s.Client.Put(fmt.Sprintf("/campaigns/%d/adgroups/%d/negativekeywords/bulk", campaignID, adGroupID), updates, &updated)
+	return updated, err
+}
+
 func (s *KeywordService) DeleteAdGroupNegativeKeywords(campaignID, adGroupID int64, keywordIDs []int64) error {
 	path := fmt.Sprintf("/campaigns/%d/adgroups/%d/negativekeywords/delete/bulk", campaignID, adGroupID)
 	_, err := s.Client.Post(path, keywordIDs, nil)
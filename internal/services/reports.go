@@ -3,6 +3,9 @@ package services
 import (
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"time"
 
 	"github.com/trebuhs/asa-cli/internal/api"
 	"github.com/trebuhs/asa-cli/internal/models"
@@ -51,3 +54,77 @@ func (s *ReportingService) getReport(path string, req *models.ReportRequest) (*m
 
 	return &resp.ReportingDataResponse, nil
 }
+
+func (s *ReportingService) CreateImpressionShareReport(req *models.ImpressionShareReportRequest) (*models.ImpressionShareReport, error) {
+	var report models.ImpressionShareReport
+	_, err := s.Client.Post("/custom-reports", req, &report)
+	return &report, err
+}
+
+func (s *ReportingService) GetImpressionShareReport(id int64) (*models.ImpressionShareReport, error) {
+	var report models.ImpressionShareReport
+	_, err := s.Client.Get(fmt.Sprintf("/custom-reports/%d", id), &report)
+	return &report, err
+}
+
+// ListImpressionShareReports lists impression share reports, newest first.
+func (s *ReportingService) ListImpressionShareReports(limit, offset int) ([]models.ImpressionShareReport, *models.PageDetail, error) {
+	var reports []models.ImpressionShareReport
+	path := fmt.Sprintf("/custom-reports?field=creationTime&sortOrder=DESCENDING&limit=%d&offset=%d", limit, offset)
+	page, err := s.Client.Get(path, &reports)
+	return reports, page, err
+}
+
+// Impression share reports are processed asynchronously; polling starts at
+// the first interval and backs off to the maximum.
+const (
+	customReportFirstPoll = 5 * time.Second
+	customReportMaxPoll   = time.Minute
+)
+
+// WaitForImpressionShareReport polls a report until it is COMPLETED or
+// FAILED, or timeout passes. onPoll, if set, is called with every state seen.
+func (s *ReportingService) WaitForImpressionShareReport(id int64, timeout time.Duration, onPoll func(*models.ImpressionShareReport)) (*models.ImpressionShareReport, error) {
+	deadline := time.Now().Add(timeout)
+	interval := customReportFirstPoll
+	for {
+		report, err := s.GetImpressionShareReport(id)
+		if err != nil {
+			return nil, err
+		}
+		if onPoll != nil {
+			onPoll(report)
+		}
+		switch report.State {
+		case "COMPLETED":
+			return report, nil
+		case "FAILED":
+			return nil, fmt.Errorf("impression share report %d failed", id)
+		}
+		if time.Now().Add(interval).After(deadline) {
+			return nil, fmt.Errorf("impression share report %d is still %s after %s", id, report.State, timeout)
+		}
+		time.Sleep(interval)
+		if interval *= 2; interval > customReportMaxPoll {
+			interval = customReportMaxPoll
+		}
+	}
+}
+
+// DownloadImpressionShareReport fetches the CSV of a completed report. The
+// download URI is pre-signed, so it is fetched without the API's
+// credentials.
+func (s *ReportingService) DownloadImpressionShareReport(report *models.ImpressionShareReport) ([]byte, error) {
+	if report.DownloadURI == "" {
+		return nil, fmt.Errorf("impression share report %d has no download URI (state %s)", report.ID, report.State)
+	}
+	resp, err := (&http.Client{Timeout: 2 * time.Minute}).Get(report.DownloadURI)
+	if err != nil {
+		return nil, fmt.Errorf("downloading impression share report %d: %w", report.ID, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("downloading impression share report %d: HTTP %d", report.ID, resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
@@ -0,0 +1,93 @@
+// Package serving explains the serving state reasons the API reports for
+// campaigns, ad groups and countries that are not running.
+package serving
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type reason struct {
+	text string
+	// fix is an asa-cli command or an instruction. {campaign} and {adgroup}
+	// are replaced with the IDs of the entity that is not running.
+	fix string
+}
+
+var reasons = map[string]reason{
+	// Campaigns.
+	"AD_GROUP_MISSING":               {"the campaign has no ad groups", "asa-cli adgroups create --campaign-id {campaign} --name <name> --default-bid <amount>"},
+	"NO_AVAILABLE_AD_GROUPS":         {"none of the campaign's ad groups can run", "fix the ad groups listed for this campaign"},
+	"APP_NOT_ELIGIBLE":               {"the app is not eligible to be advertised", "check the app's age rating and availability in App Store Connect"},
+	"APP_NOT_ELIGIBLE_SEARCHADS":     {"the app is not eligible for Search Ads", "check the app's category and content against the Search Ads policies"},
+	"APP_NOT_PUBLISHED_YET":          {"the app is not published on the App Store yet", "wait for the app to go live"},
+	"APP_NOT_ELIGIBLE_SUPPLY_SOURCE": {"the app is not eligible for the campaign's placement", "create a campaign for a placement the app supports"},
+	"BO_START_DATE_IN_FUTURE":        {"the budget order has not started yet", "asa-cli budget-orders list shows start dates; assign one that has started"},
+	"BO_END_DATE_REACHED":            {"the budget order has ended", "asa-cli budget-orders update <id> --end-date <date>, or assign a new budget order"},
+	"BO_EXHAUSTED":                   {"the budget order is used up", "asa-cli budget-orders update <id> --budget <amount>, or assign a new budget order"},
+	"CAMPAIGN_START_DATE_IN_FUTURE":  {"the campaign has not started yet", "wait for the start date"},
+	"CAMPAIGN_END_DATE_REACHED":      {"the campaign's end date has passed", "create a new campaign (asa-cli campaigns clone {campaign} --countries <codes>)"},
+	"CREDIT_CARD_DECLINED":           {"the credit card on file was declined", "update the payment method in the Search Ads account settings"},
+	"DAILY_CAP_EXHAUSTED":            {"the daily budget is spent for today", "asa-cli campaigns update {campaign} --daily-budget <amount>"},
+	"TOTAL_BUDGET_EXHAUSTED":         {"the total budget is spent", "asa-cli campaigns update {campaign} --budget <amount>"},
+	"LOC_EXHAUSTED":                  {"the line of credit is used up", "raise the line of credit or add a budget order"},
+	"MISSING_BO_OR_INVOICING_FIELDS": {"the campaign has no budget order or LOC invoice details", "create one with asa-cli budget-orders create and assign it to the campaign in the Search Ads UI"},
+	"NO_ELIGIBLE_COUNTRIES":          {"the app cannot run in any of the campaign's countries", "check the per-country reasons and the app's availability"},
+	"NO_PAYMENT_METHOD_ON_FILE":      {"the account has no payment method", "add a payment method in the Search Ads account settings"},
+	"ORG_CHARGE_BACK_DISPUTED":       {"a charge on the account is disputed", "contact Search Ads support"},
+	"ORG_PAYMENT_TYPE_CHANGED":       {"the account's payment type changed", "review the payment settings in the Search Ads account settings"},
+	"ORG_SUSPENDED_FRAUD":            {"the account is suspended", "contact Search Ads support"},
+	"ORG_SUSPENDED_POLICY_VIOLATION": {"the account is suspended for a policy violation", "contact Search Ads support"},
+	"PAUSED_BY_SYSTEM":               {"the campaign was paused by Apple", "contact Search Ads support"},
+	"SAPIN_LAW_AGENT_UNKNOWN":        {"the campaign targets France and the Sapin law agent is unknown", "complete the Sapin law details in the Search Ads account settings"},
+	"SAPIN_LAW_FRENCH_BIZ_UNKNOWN":   {"the campaign targets France and the business's location is unknown", "complete the Sapin law details in the Search Ads account settings"},
+	"SAPIN_LAW_FRENCH_BIZ":           {"the campaign targets France and the Sapin law details are incomplete", "complete the Sapin law details in the Search Ads account settings"},
+
+	// Ad groups.
+	"ADGROUP_START_DATE_IN_FUTURE":  {"the ad group has not started yet", "asa-cli adgroups update {adgroup} --campaign-id {campaign} --start-time <time>"},
+	"ADGROUP_END_DATE_REACHED":      {"the ad group's end date has passed", "asa-cli adgroups update {adgroup} --campaign-id {campaign} --end-time <time>"},
+	"AUDIENCE_BELOW_THRESHOLD":      {"the targeted audience is too small", "asa-cli adgroups targeting show {adgroup} --campaign-id {campaign}, then widen it with adgroups update"},
+	"PENDING_AUDIENCE_VERIFICATION": {"the targeted audience is being verified", "wait for verification to finish"},
+	"CAMPAIGN_NOT_RUNNING":          {"the campaign is not running", "fix the campaign first"},
+	"APP_NOT_SUPPORT":               {"the app does not support the ad group's devices", "asa-cli adgroups update {adgroup} --campaign-id {campaign} --device <devices>"},
+
+	// Both.
+	"PAUSED_BY_USER":  {"paused", "set --status ENABLED with campaigns update or adgroups update"},
+	"DELETED_BY_USER": {"deleted", "nothing to fix"},
+}
+
+// Explain returns a description of a reason code and a suggested fix for the
+// campaign or ad group it was reported for (adGroupID 0 for a campaign).
+// Unknown codes are described by the code itself, without a fix.
+func Explain(code string, campaignID, adGroupID int64) (text, fix string) {
+	r, ok := reasons[code]
+	if !ok {
+		return strings.ToLower(strings.ReplaceAll(code, "_", " ")), ""
+	}
+	fix = strings.NewReplacer(
+		"{campaign}", strconv.FormatInt(campaignID, 10),
+		"{adgroup}", strconv.FormatInt(adGroupID, 10),
+	).Replace(r.fix)
+	return r.text, fix
+}
+
+// CountryReasons converts a campaign's countryOrRegionServingStateReasons,
+// decoded as generic JSON, to reason codes per country, in country order.
+func CountryReasons(raw map[string]interface{}) ([]string, map[string][]string) {
+	byCountry := map[string][]string{}
+	for country, v := range raw {
+		list, _ := v.([]interface{})
+		for _, item := range list {
+			if s, ok := item.(string); ok {
+				byCountry[country] = append(byCountry[country], s)
+			}
+		}
+	}
+	countries := make([]string, 0, len(byCountry))
+	for country := range byCountry {
+		countries = append(countries, country)
+	}
+	sort.Strings(countries)
+	return countries, byCountry
+}
@@ -0,0 +1,57 @@
+package state
+
+import (
+	"strconv"
+
+	"github.com/trebuhs/asa-cli/internal/models"
+)
+
+// ForCountry returns a copy of the campaign tree retargeted at a single
+// storefront. Bids and CPA goals are multiplied by bidFactor, budgets by
+// budgetFactor, and geo targeting below country level is dropped because it
+// does not carry across storefronts.
+func (c *Campaign) ForCountry(name, country string, bidFactor, budgetFactor float64) *Campaign {
+	out := &Campaign{
+		Campaign:         c.Campaign,
+		NegativeKeywords: append([]models.NegativeKeyword(nil), c.NegativeKeywords...),
+	}
+	out.Campaign.ID = 0
+	out.Campaign.Name = name
+	out.Campaign.CountriesOrRegions = []string{country}
+	out.Campaign.BudgetAmount = scaleMoney(c.Campaign.BudgetAmount, budgetFactor)
+	out.Campaign.DailyBudgetAmount = scaleMoney(c.Campaign.DailyBudgetAmount, budgetFactor)
+
+	for _, ag := range c.AdGroups {
+		copied := AdGroup{
+			AdGroup:          ag.AdGroup,
+			NegativeKeywords: append([]models.NegativeKeyword(nil), ag.NegativeKeywords...),
+		}
+		copied.AdGroup.DefaultBidAmount = scaleMoney(ag.AdGroup.DefaultBidAmount, bidFactor)
+		copied.AdGroup.CpaGoal = scaleMoney(ag.AdGroup.CpaGoal, bidFactor)
+		if td := ag.AdGroup.TargetingDimensions; td != nil {
+			geoless := *td
+			geoless.Country = nil
+			geoless.AdminArea = nil
+			geoless.Locality = nil
+			copied.AdGroup.TargetingDimensions = &geoless
+		}
+		for _, kw := range ag.Keywords {
+			kw.BidAmount = scaleMoney(kw.BidAmount, bidFactor)
+			copied.Keywords = append(copied.Keywords, kw)
+		}
+		out.AdGroups = append(out.AdGroups, copied)
+	}
+	return out
+}
+
+// scaleMoney multiplies an amount, rounding to two decimals.
+func scaleMoney(m *models.Money, factor float64) *models.Money {
+	if m == nil || factor == 1 {
+		return m
+	}
+	amount, err := strconv.ParseFloat(m.Amount, 64)
+	if err != nil {
+		return m
+	}
+	return &models.Money{Amount: strconv.FormatFloat(amount*factor, 'f', 2, 64), Currency: m.Currency}
+}
@@ -0,0 +1,250 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/trebuhs/asa-cli/internal/models"
+)
+
+// ChangeType classifies a difference between two account states.
+type ChangeType string
+
+const (
+	Added   ChangeType = "added"
+	Removed ChangeType = "removed"
+	Changed ChangeType = "changed"
+)
+
+// MatchBy selects how entities in the two states are paired up.
+type MatchBy string
+
+const (
+	// MatchByID pairs entities by API ID; renames show up as changes.
+	MatchByID MatchBy = "id"
+	// MatchByName pairs campaigns and ad groups by name and keywords by text
+	// and match type, for comparing snapshots from different orgs.
+	MatchByName MatchBy = "name"
+)
+
+// FieldDiff is one attribute that differs between the two states.
+type FieldDiff struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// Difference is an entity that was added, removed or changed.
+type Difference struct {
+	Change ChangeType  `json:"change"`
+	Kind   string      `json:"kind"`
+	Path   string      `json:"path"`
+	ID     int64       `json:"id,omitempty"`
+	Fields []FieldDiff `json:"fields,omitempty"`
+}
+
+// Diff reports how account b differs from account a. Added and removed
+// campaigns and ad groups are reported once, without their children.
+func Diff(a, b *Account, by MatchBy) []Difference {
+	var diffs []Difference
+
+	bIndex := map[string]*Campaign{}
+	for i := range b.Campaigns {
+		bIndex[campaignKey(&b.Campaigns[i].Campaign, by)] = &b.Campaigns[i]
+	}
+	seen := map[string]bool{}
+
+	for i := range a.Campaigns {
+		old := &a.Campaigns[i]
+		key := campaignKey(&old.Campaign, by)
+		seen[key] = true
+		cur, ok := bIndex[key]
+		if !ok {
+			diffs = append(diffs, Difference{Change: Removed, Kind: "campaign", Path: old.Campaign.Name, ID: old.Campaign.ID})
+			continue
+		}
+		diffs = append(diffs, diffCampaign(old, cur, by)...)
+	}
+	for i := range b.Campaigns {
+		cur := &b.Campaigns[i]
+		if !seen[campaignKey(&cur.Campaign, by)] {
+			diffs = append(diffs, Difference{Change: Added, Kind: "campaign", Path: cur.Campaign.Name, ID: cur.Campaign.ID})
+		}
+	}
+	return diffs
+}
+
+func diffCampaign(old, cur *Campaign, by MatchBy) []Difference {
+	var diffs []Difference
+	path := cur.Campaign.Name
+
+	var fields []FieldDiff
+	fields = diffField(fields, "name", old.Campaign.Name, cur.Campaign.Name)
+	fields = diffField(fields, "status", old.Campaign.Status, cur.Campaign.Status)
+	fields = diffField(fields, "budget", moneyString(old.Campaign.BudgetAmount), moneyString(cur.Campaign.BudgetAmount))
+	fields = diffField(fields, "daily_budget", moneyString(old.Campaign.DailyBudgetAmount), moneyString(cur.Campaign.DailyBudgetAmount))
+	fields = diffField(fields, "countries", sortedJoin(old.Campaign.CountriesOrRegions), sortedJoin(cur.Campaign.CountriesOrRegions))
+	if len(fields) > 0 {
+		diffs = append(diffs, Difference{Change: Changed, Kind: "campaign", Path: path, ID: cur.Campaign.ID, Fields: fields})
+	}
+
+	diffs = append(diffs, diffNegatives("campaign-negative", path, old.NegativeKeywords, cur.NegativeKeywords)...)
+
+	curIndex := map[string]*AdGroup{}
+	for i := range cur.AdGroups {
+		curIndex[adGroupKey(&cur.AdGroups[i].AdGroup, by)] = &cur.AdGroups[i]
+	}
+	seen := map[string]bool{}
+	for i := range old.AdGroups {
+		oldAG := &old.AdGroups[i]
+		key := adGroupKey(&oldAG.AdGroup, by)
+		seen[key] = true
+		curAG, ok := curIndex[key]
+		if !ok {
+			diffs = append(diffs, Difference{Change: Removed, Kind: "adgroup", Path: path + " / " + oldAG.AdGroup.Name, ID: oldAG.AdGroup.ID})
+			continue
+		}
+		diffs = append(diffs, diffAdGroup(path, oldAG, curAG, by)...)
+	}
+	for i := range cur.AdGroups {
+		curAG := &cur.AdGroups[i]
+		if !seen[adGroupKey(&curAG.AdGroup, by)] {
+			diffs = append(diffs, Difference{Change: Added, Kind: "adgroup", Path: path + " / " + curAG.AdGroup.Name, ID: curAG.AdGroup.ID})
+		}
+	}
+	return diffs
+}
+
+func diffAdGroup(parent string, old, cur *AdGroup, by MatchBy) []Difference {
+	var diffs []Difference
+	path := parent + " / " + cur.AdGroup.Name
+
+	var fields []FieldDiff
+	fields = diffField(fields, "name", old.AdGroup.Name, cur.AdGroup.Name)
+	fields = diffField(fields, "status", old.AdGroup.Status, cur.AdGroup.Status)
+	fields = diffField(fields, "default_bid", moneyString(old.AdGroup.DefaultBidAmount), moneyString(cur.AdGroup.DefaultBidAmount))
+	fields = diffField(fields, "cpa_goal", moneyString(old.AdGroup.CpaGoal), moneyString(cur.AdGroup.CpaGoal))
+	fields = diffField(fields, "auto_keywords", strconv.FormatBool(old.AdGroup.AutomatedKeywordsOptIn), strconv.FormatBool(cur.AdGroup.AutomatedKeywordsOptIn))
+	fields = diffField(fields, "targeting", jsonString(old.AdGroup.TargetingDimensions), jsonString(cur.AdGroup.TargetingDimensions))
+	if len(fields) > 0 {
+		diffs = append(diffs, Difference{Change: Changed, Kind: "adgroup", Path: path, ID: cur.AdGroup.ID, Fields: fields})
+	}
+
+	curIndex := map[string]*models.Keyword{}
+	for i := range cur.Keywords {
+		curIndex[keywordKey(&cur.Keywords[i], by)] = &cur.Keywords[i]
+	}
+	seen := map[string]bool{}
+	for i := range old.Keywords {
+		oldKW := &old.Keywords[i]
+		key := keywordKey(oldKW, by)
+		seen[key] = true
+		kwPath := fmt.Sprintf("%s / %s (%s)", path, oldKW.Text, oldKW.MatchType)
+		curKW, ok := curIndex[key]
+		if !ok {
+			diffs = append(diffs, Difference{Change: Removed, Kind: "keyword", Path: kwPath, ID: oldKW.ID})
+			continue
+		}
+		var kwFields []FieldDiff
+		kwFields = diffField(kwFields, "match_type", oldKW.MatchType, curKW.MatchType)
+		kwFields = diffField(kwFields, "status", oldKW.Status, curKW.Status)
+		kwFields = diffField(kwFields, "bid", moneyString(oldKW.BidAmount), moneyString(curKW.BidAmount))
+		if len(kwFields) > 0 {
+			diffs = append(diffs, Difference{Change: Changed, Kind: "keyword", Path: kwPath, ID: curKW.ID, Fields: kwFields})
+		}
+	}
+	for i := range cur.Keywords {
+		curKW := &cur.Keywords[i]
+		if !seen[keywordKey(curKW, by)] {
+			diffs = append(diffs, Difference{Change: Added, Kind: "keyword", Path: fmt.Sprintf("%s / %s (%s)", path, curKW.Text, curKW.MatchType), ID: curKW.ID})
+		}
+	}
+
+	diffs = append(diffs, diffNegatives("adgroup-negative", path, old.NegativeKeywords, cur.NegativeKeywords)...)
+	return diffs
+}
+
+// diffNegatives compares negative keyword sets by text and match type.
+func diffNegatives(kind, parent string, old, cur []models.NegativeKeyword) []Difference {
+	var diffs []Difference
+	curIndex := map[string]models.NegativeKeyword{}
+	for _, nk := range cur {
+		curIndex[models.Key(nk.Text, nk.MatchType)] = nk
+	}
+	seen := map[string]bool{}
+	for _, nk := range old {
+		key := models.Key(nk.Text, nk.MatchType)
+		seen[key] = true
+		path := fmt.Sprintf("%s / -%s (%s)", parent, nk.Text, nk.MatchType)
+		curNK, ok := curIndex[key]
+		if !ok {
+			diffs = append(diffs, Difference{Change: Removed, Kind: kind, Path: path, ID: nk.ID})
+			continue
+		}
+		if fields := diffField(nil, "status", nk.Status, curNK.Status); len(fields) > 0 {
+			diffs = append(diffs, Difference{Change: Changed, Kind: kind, Path: path, ID: curNK.ID, Fields: fields})
+		}
+	}
+	for _, nk := range cur {
+		if !seen[models.Key(nk.Text, nk.MatchType)] {
+			diffs = append(diffs, Difference{Change: Added, Kind: kind, Path: fmt.Sprintf("%s / -%s (%s)", parent, nk.Text, nk.MatchType), ID: nk.ID})
+		}
+	}
+	return diffs
+}
+
+func campaignKey(c *models.Campaign, by MatchBy) string {
+	if by == MatchByName {
+		return c.Name
+	}
+	return strconv.FormatInt(c.ID, 10)
+}
+
+func adGroupKey(ag *models.AdGroup, by MatchBy) string {
+	if by == MatchByName {
+		return ag.Name
+	}
+	return strconv.FormatInt(ag.ID, 10)
+}
+
+func keywordKey(kw *models.Keyword, by MatchBy) string {
+	if by == MatchByName {
+		return models.Key(kw.Text, kw.MatchType)
+	}
+	return strconv.FormatInt(kw.ID, 10)
+}
+
+func diffField(fields []FieldDiff, name, old, cur string) []FieldDiff {
+	if old != cur {
+		fields = append(fields, FieldDiff{Field: name, Old: old, New: cur})
+	}
+	return fields
+}
+
+// moneyString normalizes an amount so "1.5" and "1.50" compare equal.
+func moneyString(m *models.Money) string {
+	if m == nil || m.Amount == "" {
+		return ""
+	}
+	if f, err := strconv.ParseFloat(m.Amount, 64); err == nil {
+		return strconv.FormatFloat(f, 'f', 2, 64) + " " + m.Currency
+	}
+	return m.Amount + " " + m.Currency
+}
+
+func sortedJoin(values []string) string {
+	sorted := append([]string(nil), values...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
+
+func jsonString(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil || string(data) == "null" {
+		return ""
+	}
+	return string(data)
+}
@@ -0,0 +1,136 @@
+package state
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/trebuhs/asa-cli/internal/models"
+)
+
+// account returns a one-campaign account. ids offsets every ID so that two
+// accounts from different orgs share names but not IDs.
+func account(ids int64, bid string) *Account {
+	return &Account{Campaigns: []Campaign{{
+		Campaign:         models.Campaign{ID: ids + 1, Name: "Brand", Status: "ENABLED", DailyBudgetAmount: &models.Money{Amount: "50", Currency: "USD"}},
+		NegativeKeywords: []models.NegativeKeyword{{ID: ids + 10, Text: "free", MatchType: "EXACT", Status: "ACTIVE"}},
+		AdGroups: []AdGroup{{
+			AdGroup:  models.AdGroup{ID: ids + 2, Name: "Exact", Status: "ENABLED"},
+			Keywords: []models.Keyword{{ID: ids + 20, Text: "habit tracker", MatchType: "EXACT", Status: "ACTIVE", BidAmount: &models.Money{Amount: bid, Currency: "USD"}}},
+		}},
+	}}}
+}
+
+// summary renders each difference as "change kind path [fields]".
+func summary(diffs []Difference) []string {
+	var lines []string
+	for _, d := range diffs {
+		line := fmt.Sprintf("%s %s %s", d.Change, d.Kind, d.Path)
+		for _, f := range d.Fields {
+			line += fmt.Sprintf(" %s:%s->%s", f.Field, f.Old, f.New)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b *Account
+		by   MatchBy
+		want []string
+	}{
+		{
+			name: "identical",
+			a:    account(0, "1.00"),
+			b:    account(0, "1.00"),
+			by:   MatchByID,
+		},
+		{
+			name: "amounts compare numerically",
+			a:    account(0, "1"),
+			b:    account(0, "1.00"),
+			by:   MatchByID,
+		},
+		{
+			name: "changed bid",
+			a:    account(0, "1.00"),
+			b:    account(0, "1.25"),
+			by:   MatchByID,
+			want: []string{"changed keyword Brand / Exact / habit tracker (EXACT) bid:1.00 USD->1.25 USD"},
+		},
+		{
+			name: "other org by id",
+			a:    account(0, "1.00"),
+			b:    account(100, "1.00"),
+			by:   MatchByID,
+			want: []string{"removed campaign Brand", "added campaign Brand"},
+		},
+		{
+			name: "other org by name",
+			a:    account(0, "1.00"),
+			b:    account(100, "1.25"),
+			by:   MatchByName,
+			want: []string{"changed keyword Brand / Exact / habit tracker (EXACT) bid:1.00 USD->1.25 USD"},
+		},
+		{
+			name: "by name pairs keywords ignoring case and spacing",
+			a:    account(0, "1.00"),
+			b: func() *Account {
+				acc := account(100, "1.00")
+				acc.Campaigns[0].AdGroups[0].Keywords[0].Text = "Habit  Tracker"
+				return acc
+			}(),
+			by: MatchByName,
+		},
+		{
+			name: "renamed ad group by id",
+			a:    account(0, "1.00"),
+			b: func() *Account {
+				acc := account(0, "1.00")
+				acc.Campaigns[0].AdGroups[0].AdGroup.Name = "Exact US"
+				return acc
+			}(),
+			by:   MatchByID,
+			want: []string{"changed adgroup Brand / Exact US name:Exact->Exact US"},
+		},
+		{
+			name: "renamed ad group by name",
+			a:    account(0, "1.00"),
+			b: func() *Account {
+				acc := account(0, "1.00")
+				acc.Campaigns[0].AdGroups[0].AdGroup.Name = "Exact US"
+				return acc
+			}(),
+			by:   MatchByName,
+			want: []string{"removed adgroup Brand / Exact", "added adgroup Brand / Exact US"},
+		},
+		{
+			name: "negatives",
+			a:    account(0, "1.00"),
+			b: func() *Account {
+				acc := account(0, "1.00")
+				acc.Campaigns[0].NegativeKeywords = []models.NegativeKeyword{
+					{ID: 10, Text: "FREE", MatchType: "EXACT", Status: "PAUSED"},
+					{ID: 11, Text: "cheap", MatchType: "BROAD", Status: "ACTIVE"},
+				}
+				return acc
+			}(),
+			by: MatchByID,
+			want: []string{
+				"changed campaign-negative Brand / -free (EXACT) status:ACTIVE->PAUSED",
+				"added campaign-negative Brand / -cheap (BROAD)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := summary(Diff(tt.a, tt.b, tt.by)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Diff =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
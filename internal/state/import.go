@@ -0,0 +1,188 @@
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/trebuhs/asa-cli/internal/api"
+	"github.com/trebuhs/asa-cli/internal/models"
+	"github.com/trebuhs/asa-cli/internal/services"
+)
+
+const apiTimeLayout = "2006-01-02T15:04:05.000"
+
+// IDMapping records the ID each imported entity received in the target org.
+type IDMapping struct {
+	Kind  string `json:"kind"`
+	Name  string `json:"name"`
+	OldID int64  `json:"oldId"`
+	NewID int64  `json:"newId"`
+}
+
+// Importer recreates account structure in the org the client points at.
+type Importer struct {
+	Client   *api.Client
+	Currency string
+	// Status, if set, overrides the status of every created campaign.
+	Status string
+	// Rate converts amounts into the target org's currency; 0 leaves them
+	// unchanged, for a target with the same currency.
+	Rate float64
+	// CopyInvoiceDetails copies the campaigns' LOC invoice details, which
+	// belong to the source org's billing setup and are dropped otherwise.
+	CopyInvoiceDetails bool
+	// OnCreate, if set, is called after each entity is created.
+	OnCreate func(m IDMapping)
+
+	Mappings []IDMapping
+}
+
+// NewImporter creates an Importer that sends amounts in the given currency.
+func NewImporter(client *api.Client, currency string) *Importer {
+	return &Importer{Client: client, Currency: currency}
+}
+
+// ImportAccount recreates every campaign in the account.
+func (im *Importer) ImportAccount(account *Account) error {
+	for i := range account.Campaigns {
+		if _, err := im.ImportCampaign(&account.Campaigns[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ImportCampaign creates the campaign, its negative keywords, ad groups,
+// keywords and ad group negative keywords, and returns the new campaign ID.
+func (im *Importer) ImportCampaign(src *Campaign) (int64, error) {
+	campSvc := services.NewCampaignService(im.Client)
+	agSvc := services.NewAdGroupService(im.Client)
+	kwSvc := services.NewKeywordService(im.Client)
+
+	c := src.Campaign
+	status := c.Status
+	if im.Status != "" {
+		status = im.Status
+	}
+	campaign := &models.Campaign{
+		Name:               c.Name,
+		AdamID:             c.AdamID,
+		Status:             status,
+		CountriesOrRegions: c.CountriesOrRegions,
+		BudgetAmount:       im.money(c.BudgetAmount),
+		DailyBudgetAmount:  im.money(c.DailyBudgetAmount),
+		AdChannelType:      c.AdChannelType,
+		SupplySources:      c.SupplySources,
+		BillingEvent:       c.BillingEvent,
+		EndTime:            futureTime(c.EndTime),
+	}
+	if im.CopyInvoiceDetails {
+		campaign.LOCInvoiceDetails = c.LOCInvoiceDetails
+	}
+	created, err := campSvc.Create(campaign)
+	if err != nil {
+		return 0, fmt.Errorf("creating campaign %q: %w", c.Name, err)
+	}
+	im.record("campaign", c.Name, c.ID, created.ID)
+	campaignID := created.ID
+
+	if negatives := copyNegatives(src.NegativeKeywords); len(negatives) > 0 {
+		for _, batch := range services.Chunk(negatives, services.KeywordBulkLimit) {
+			if _, err := kwSvc.CreateCampaignNegativeKeywords(campaignID, batch); err != nil {
+				return 0, fmt.Errorf("creating negative keywords for campaign %q: %w", c.Name, err)
+			}
+		}
+	}
+
+	for _, ag := range src.AdGroups {
+		a := ag.AdGroup
+		newAG, err := agSvc.Create(campaignID, &models.AdGroup{
+			Name:                   a.Name,
+			Status:                 a.Status,
+			DefaultBidAmount:       im.money(a.DefaultBidAmount),
+			CpaGoal:                im.money(a.CpaGoal),
+			AutomatedKeywordsOptIn: a.AutomatedKeywordsOptIn,
+			StartTime:              futureTime(a.StartTime),
+			EndTime:                futureTime(a.EndTime),
+			TargetingDimensions:    a.TargetingDimensions,
+			PricingModel:           a.PricingModel,
+		})
+		if err != nil {
+			return 0, fmt.Errorf("creating ad group %q: %w", a.Name, err)
+		}
+		im.record("adgroup", a.Name, a.ID, newAG.ID)
+
+		var keywords []models.Keyword
+		for _, kw := range ag.Keywords {
+			keywords = append(keywords, models.Keyword{
+				Text:      kw.Text,
+				MatchType: kw.MatchType,
+				Status:    kw.Status,
+				BidAmount: im.money(kw.BidAmount),
+			})
+		}
+		start := 0
+		for _, batch := range services.Chunk(keywords, services.KeywordBulkLimit) {
+			createdKWs, err := kwSvc.Create(campaignID, newAG.ID, batch)
+			if err != nil {
+				return 0, fmt.Errorf("creating keywords for ad group %q: %w", a.Name, err)
+			}
+			for i, kw := range createdKWs {
+				if start+i < len(ag.Keywords) {
+					im.record("keyword", kw.Text, ag.Keywords[start+i].ID, kw.ID)
+				}
+			}
+			start += len(batch)
+		}
+
+		if negatives := copyNegatives(ag.NegativeKeywords); len(negatives) > 0 {
+			for _, batch := range services.Chunk(negatives, services.KeywordBulkLimit) {
+				if _, err := kwSvc.CreateAdGroupNegativeKeywords(campaignID, newAG.ID, batch); err != nil {
+					return 0, fmt.Errorf("creating negative keywords for ad group %q: %w", a.Name, err)
+				}
+			}
+		}
+	}
+
+	return campaignID, nil
+}
+
+func (im *Importer) record(kind, name string, oldID, newID int64) {
+	m := IDMapping{Kind: kind, Name: name, OldID: oldID, NewID: newID}
+	im.Mappings = append(im.Mappings, m)
+	if im.OnCreate != nil {
+		im.OnCreate(m)
+	}
+}
+
+// money converts an amount into the target org's currency.
+func (im *Importer) money(m *models.Money) *models.Money {
+	if m == nil || m.Amount == "" {
+		return nil
+	}
+	if im.Rate != 0 {
+		m = scaleMoney(m, im.Rate)
+	}
+	return &models.Money{Amount: m.Amount, Currency: im.Currency}
+}
+
+func copyNegatives(src []models.NegativeKeyword) []models.NegativeKeyword {
+	var out []models.NegativeKeyword
+	for _, nk := range src {
+		out = append(out, models.NegativeKeyword{Text: nk.Text, MatchType: nk.MatchType, Status: nk.Status})
+	}
+	return out
+}
+
+// futureTime drops timestamps that are already in the past, which the API
+// rejects on create.
+func futureTime(ts string) string {
+	if ts == "" {
+		return ""
+	}
+	t, err := time.Parse(apiTimeLayout, ts)
+	if err != nil || t.After(time.Now().UTC()) {
+		return ts
+	}
+	return ""
+}
@@ -0,0 +1,57 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// SnapshotVersion is the current snapshot document format.
+const SnapshotVersion = 1
+
+// Snapshot is a versioned export of an org's account structure.
+type Snapshot struct {
+	Version    int       `json:"version"`
+	ExportedAt time.Time `json:"exportedAt"`
+	OrgID      string    `json:"orgId,omitempty"`
+	Account
+}
+
+// NewSnapshot wraps an account in a snapshot stamped with the current time.
+func NewSnapshot(orgID string, account *Account) *Snapshot {
+	return &Snapshot{
+		Version:    SnapshotVersion,
+		ExportedAt: time.Now().UTC(),
+		OrgID:      orgID,
+		Account:    *account,
+	}
+}
+
+// ReadSnapshot loads a snapshot from disk and checks its version.
+func ReadSnapshot(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading snapshot: %w", err)
+	}
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("parsing snapshot %s: %w", path, err)
+	}
+	if snap.Version == 0 || snap.Version > SnapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d in %s (expected %d)", snap.Version, path, SnapshotVersion)
+	}
+	return &snap, nil
+}
+
+// WriteSnapshot writes a snapshot as indented JSON.
+func WriteSnapshot(path string, snap *Snapshot) error {
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding snapshot: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("writing snapshot: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package state
+
+import (
+	"fmt"
+
+	"github.com/trebuhs/asa-cli/internal/api"
+	"github.com/trebuhs/asa-cli/internal/models"
+	"github.com/trebuhs/asa-cli/internal/services"
+)
+
+// Account is the live structure of an org: campaigns with their ad groups,
+// targeting keywords and negative keywords.
+type Account struct {
+	Campaigns []Campaign `json:"campaigns"`
+}
+
+// Campaign is a campaign together with everything nested under it.
+type Campaign struct {
+	Campaign         models.Campaign          `json:"campaign"`
+	NegativeKeywords []models.NegativeKeyword `json:"negativeKeywords,omitempty"`
+	AdGroups         []AdGroup                `json:"adGroups,omitempty"`
+}
+
+// AdGroup is an ad group together with its keywords and negative keywords.
+type AdGroup struct {
+	AdGroup          models.AdGroup           `json:"adGroup"`
+	Keywords         []models.Keyword         `json:"keywords,omitempty"`
+	NegativeKeywords []models.NegativeKeyword `json:"negativeKeywords,omitempty"`
+}
+
+// Fetch walks every campaign accepted by include (nil accepts all) and loads
+// its ad groups, keywords and negative keywords. Deleted entities are skipped.
+func Fetch(client *api.Client, include func(models.Campaign) bool) (*Account, error) {
+	campaigns, err := services.NewCampaignService(client).FindAll(models.NewSelector(1000, 0))
+	if err != nil {
+		return nil, fmt.Errorf("fetching campaigns: %w", err)
+	}
+
+	account := &Account{}
+	for _, c := range campaigns {
+		if c.Deleted || (include != nil && !include(c)) {
+			continue
+		}
+		tree, err := fetchChildren(client, c)
+		if err != nil {
+			return nil, err
+		}
+		account.Campaigns = append(account.Campaigns, *tree)
+	}
+	return account, nil
+}
+
+// FetchCampaign loads a single campaign and everything nested under it.
+func FetchCampaign(client *api.Client, campaignID int64) (*Campaign, error) {
+	c, err := services.NewCampaignService(client).Get(campaignID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching campaign %d: %w", campaignID, err)
+	}
+	return fetchChildren(client, *c)
+}
+
+func fetchChildren(client *api.Client, c models.Campaign) (*Campaign, error) {
+	agSvc := services.NewAdGroupService(client)
+	kwSvc := services.NewKeywordService(client)
+	tree := &Campaign{Campaign: c}
+
+	negatives, err := kwSvc.FindAllCampaignNegativeKeywords(c.ID, models.NewSelector(1000, 0))
+	if err != nil {
+		return nil, fmt.Errorf("fetching negative keywords for campaign %d: %w", c.ID, err)
+	}
+	tree.NegativeKeywords = activeNegatives(negatives)
+
+	adgroups, err := agSvc.FindAll(c.ID, models.NewSelector(1000, 0))
+	if err != nil {
+		return nil, fmt.Errorf("fetching ad groups for campaign %d: %w", c.ID, err)
+	}
+
+	for _, ag := range adgroups {
+		if ag.Deleted {
+			continue
+		}
+		keywords, err := kwSvc.FindAll(c.ID, ag.ID, models.NewSelector(1000, 0))
+		if err != nil {
+			return nil, fmt.Errorf("fetching keywords for ad group %d: %w", ag.ID, err)
+		}
+		agNegatives, err := kwSvc.FindAllAdGroupNegativeKeywords(c.ID, ag.ID, models.NewSelector(1000, 0))
+		if err != nil {
+			return nil, fmt.Errorf("fetching negative keywords for ad group %d: %w", ag.ID, err)
+		}
+
+		var active []models.Keyword
+		for _, kw := range keywords {
+			if !kw.Deleted {
+				active = append(active, kw)
+			}
+		}
+
+		tree.AdGroups = append(tree.AdGroups, AdGroup{
+			AdGroup:          ag,
+			Keywords:         active,
+			NegativeKeywords: activeNegatives(agNegatives),
+		})
+	}
+
+	return tree, nil
+}
+
+func activeNegatives(keywords []models.NegativeKeyword) []models.NegativeKeyword {
+	var active []models.NegativeKeyword
+	for _, kw := range keywords {
+		if !kw.Deleted {
+			active = append(active, kw)
+		}
+	}
+	return active
+}
+
+// FindCampaign returns the campaign with the given name, or nil.
+func (a *Account) FindCampaign(name string) *Campaign {
+	for i := range a.Campaigns {
+		if a.Campaigns[i].Campaign.Name == name {
+			return &a.Campaigns[i]
+		}
+	}
+	return nil
+}
+
+// FindAdGroup returns the ad group with the given name, or nil.
+func (c *Campaign) FindAdGroup(name string) *AdGroup {
+	for i := range c.AdGroups {
+		if c.AdGroups[i].AdGroup.Name == name {
+			return &c.AdGroups[i]
+		}
+	}
+	return nil
+}
+
+// EntityCount returns the number of entities in the campaign tree: the
+// campaign, its ad groups and all keywords and negative keywords.
+func (c *Campaign) EntityCount() int {
+	n := 1 + len(c.NegativeKeywords)
+	for _, ag := range c.AdGroups {
+		n += 1 + len(ag.Keywords) + len(ag.NegativeKeywords)
+	}
+	return n
+}
+
+// Currency returns the currency of the account's amounts, taken from the
+// first campaign budget, or "" if there are none.
+func (a *Account) Currency() string {
+	for _, c := range a.Campaigns {
+		for _, m := range []*models.Money{c.Campaign.DailyBudgetAmount, c.Campaign.BudgetAmount} {
+			if m != nil && m.Currency != "" {
+				return m.Currency
+			}
+		}
+	}
+	return ""
+}
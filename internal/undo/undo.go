@@ -0,0 +1,408 @@
+package undo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"github.com/trebuhs/asa-cli/internal/api"
+	"github.com/trebuhs/asa-cli/internal/audit"
+	"github.com/trebuhs/asa-cli/internal/models"
+	"github.com/trebuhs/asa-cli/internal/services"
+)
+
+// Step is one request that reverses part of a change.
+type Step struct {
+	Description string
+	run         func() error
+}
+
+// Plan reverses a single audit log entry. Irreversible lists the parts of the
+// change that cannot be restored; a plan with no steps has nothing to run.
+type Plan struct {
+	Change       *audit.Entry
+	Steps        []Step
+	Irreversible []string
+}
+
+// Run executes the steps in order, stopping at the first failure.
+func (p *Plan) Run() error {
+	for _, s := range p.Steps {
+		if err := s.run(); err != nil {
+			return fmt.Errorf("%s: %w", s.Description, err)
+		}
+	}
+	return nil
+}
+
+var (
+	campaignsPath       = regexp.MustCompile(`^/campaigns$`)
+	campaignPath        = regexp.MustCompile(`^/campaigns/(\d+)$`)
+	adGroupsPath        = regexp.MustCompile(`^/campaigns/(\d+)/adgroups$`)
+	adGroupPath         = regexp.MustCompile(`^/campaigns/(\d+)/adgroups/(\d+)$`)
+	keywordsPath        = regexp.MustCompile(`^/campaigns/(\d+)/adgroups/(\d+)/targetingkeywords/bulk$`)
+	keywordsDeletePath  = regexp.MustCompile(`^/campaigns/(\d+)/adgroups/(\d+)/targetingkeywords/delete/bulk$`)
+	campNegativesPath   = regexp.MustCompile(`^/campaigns/(\d+)/negativekeywords/bulk$`)
+	campNegativesDelete = regexp.MustCompile(`^/campaigns/(\d+)/negativekeywords/delete/bulk$`)
+	agNegativesPath     = regexp.MustCompile(`^/campaigns/(\d+)/adgroups/(\d+)/negativekeywords/bulk$`)
+	agNegativesDelete   = regexp.MustCompile(`^/campaigns/(\d+)/adgroups/(\d+)/negativekeywords/delete/bulk$`)
+)
+
+// Build works out how to reverse a change. Updates are reverted to the
+// pre-change snapshot for the fields the change set, deleted keywords and
+// negative keywords are recreated (with new IDs), and created entities are
+// deleted. Campaign and ad group deletes cannot be undone.
+func Build(client *api.Client, e *audit.Entry) (*Plan, error) {
+	if e.Error != "" {
+		return nil, fmt.Errorf("change %s failed (%s); nothing to undo", e.ID, e.Error)
+	}
+
+	p := &Plan{Change: e}
+	b := builder{plan: p, entry: e, campaigns: services.NewCampaignService(client),
+		adGroups: services.NewAdGroupService(client), keywords: services.NewKeywordService(client)}
+
+	var err error
+	switch m := match(e.Path); {
+	case e.Method == http.MethodDelete && m(campaignPath) != nil:
+		p.Irreversible = append(p.Irreversible, fmt.Sprintf("campaign %d was deleted; deleted campaigns cannot be restored", m(campaignPath)[0]))
+	case e.Method == http.MethodDelete && m(adGroupPath) != nil:
+		p.Irreversible = append(p.Irreversible, fmt.Sprintf("ad group %d was deleted; deleted ad groups cannot be restored", m(adGroupPath)[1]))
+
+	case e.Method == http.MethodPut && m(campaignPath) != nil:
+		err = b.revertCampaign(m(campaignPath)[0])
+	case e.Method == http.MethodPut && m(adGroupPath) != nil:
+		ids := m(adGroupPath)
+		err = b.revertAdGroup(ids[0], ids[1])
+	case e.Method == http.MethodPut && m(keywordsPath) != nil:
+		ids := m(keywordsPath)
+		err = b.revertKeywords(ids[0], ids[1])
+	case e.Method == http.MethodPut && m(campNegativesPath) != nil:
+		err = b.revertNegatives(m(campNegativesPath)[0], 0)
+	case e.Method == http.MethodPut && m(agNegativesPath) != nil:
+		ids := m(agNegativesPath)
+		err = b.revertNegatives(ids[0], ids[1])
+
+	case e.Method == http.MethodPost && m(keywordsDeletePath) != nil:
+		ids := m(keywordsDeletePath)
+		err = b.recreateKeywords(ids[0], ids[1])
+	case e.Method == http.MethodPost && m(campNegativesDelete) != nil:
+		err = b.recreateNegatives(m(campNegativesDelete)[0], 0)
+	case e.Method == http.MethodPost && m(agNegativesDelete) != nil:
+		ids := m(agNegativesDelete)
+		err = b.recreateNegatives(ids[0], ids[1])
+
+	case e.Method == http.MethodPost && m(campaignsPath) != nil:
+		for _, id := range e.EntityIDs {
+			id := id
+			b.step(fmt.Sprintf("delete created campaign %d", id), func() error {
+				return b.campaigns.Delete(id)
+			})
+		}
+	case e.Method == http.MethodPost && m(adGroupsPath) != nil:
+		campaignID := m(adGroupsPath)[0]
+		for _, id := range e.EntityIDs {
+			id := id
+			b.step(fmt.Sprintf("delete created ad group %d", id), func() error {
+				return b.adGroups.Delete(campaignID, id)
+			})
+		}
+	case e.Method == http.MethodPost && m(keywordsPath) != nil:
+		ids := m(keywordsPath)
+		b.step(fmt.Sprintf("delete %d created keyword(s) %v", len(e.EntityIDs), e.EntityIDs), func() error {
+			return b.keywords.Delete(ids[0], ids[1], e.EntityIDs)
+		})
+	case e.Method == http.MethodPost && m(campNegativesPath) != nil:
+		campaignID := m(campNegativesPath)[0]
+		b.step(fmt.Sprintf("delete %d created campaign negative keyword(s) %v", len(e.EntityIDs), e.EntityIDs), func() error {
+			return b.keywords.DeleteCampaignNegativeKeywords(campaignID, e.EntityIDs)
+		})
+	case e.Method == http.MethodPost && m(agNegativesPath) != nil:
+		ids := m(agNegativesPath)
+		b.step(fmt.Sprintf("delete %d created ad group negative keyword(s) %v", len(e.EntityIDs), e.EntityIDs), func() error {
+			return b.keywords.DeleteAdGroupNegativeKeywords(ids[0], ids[1], e.EntityIDs)
+		})
+
+	default:
+		return nil, fmt.Errorf("undo of %s %s is not supported", e.Method, e.Path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// match returns a function reporting the path IDs captured by a pattern, or
+// nil if the path does not match it.
+func match(path string) func(*regexp.Regexp) []int64 {
+	return func(re *regexp.Regexp) []int64 {
+		m := re.FindStringSubmatch(path)
+		if m == nil {
+			return nil
+		}
+		ids := []int64{}
+		for _, s := range m[1:] {
+			id, _ := strconv.ParseInt(s, 10, 64)
+			ids = append(ids, id)
+		}
+		return ids
+	}
+}
+
+type builder struct {
+	plan      *Plan
+	entry     *audit.Entry
+	campaigns *services.CampaignService
+	adGroups  *services.AdGroupService
+	keywords  *services.KeywordService
+}
+
+func (b *builder) step(description string, run func() error) {
+	b.plan.Steps = append(b.plan.Steps, Step{Description: description, run: run})
+}
+
+func (b *builder) irreversible(format string, args ...interface{}) {
+	b.plan.Irreversible = append(b.plan.Irreversible, fmt.Sprintf(format, args...))
+}
+
+// decode unmarshals the change's request and pre-change snapshot.
+func (b *builder) decode(request, before interface{}) error {
+	if len(b.entry.Before) == 0 {
+		return fmt.Errorf("change %s has no pre-change snapshot; it cannot be undone", b.entry.ID)
+	}
+	if err := json.Unmarshal(b.entry.Request, request); err != nil {
+		return fmt.Errorf("parsing recorded request: %w", err)
+	}
+	if err := json.Unmarshal(b.entry.Before, before); err != nil {
+		return fmt.Errorf("parsing recorded snapshot: %w", err)
+	}
+	return nil
+}
+
+func (b *builder) revertCampaign(id int64) error {
+	var req models.UpdateCampaignRequest
+	var before models.Campaign
+	if err := b.decode(&req, &before); err != nil {
+		return err
+	}
+	if req.Campaign == nil {
+		return nil
+	}
+	changed := req.Campaign
+
+	restore := &models.CampaignUpdate{}
+	if changed.Name != "" {
+		restore.Name = before.Name
+	}
+	if changed.Status != "" {
+		restore.Status = before.Status
+	}
+	if changed.CountriesOrRegions != nil {
+		restore.CountriesOrRegions = before.CountriesOrRegions
+	}
+	if changed.DailyBudgetAmount != nil {
+		if before.DailyBudgetAmount == nil {
+			b.irreversible("campaign %d had no daily budget; it cannot be cleared", id)
+		}
+		restore.DailyBudgetAmount = before.DailyBudgetAmount
+	}
+	if changed.BudgetAmount != nil {
+		if before.BudgetAmount == nil {
+			b.irreversible("campaign %d had no total budget; it cannot be cleared", id)
+		}
+		restore.BudgetAmount = before.BudgetAmount
+	}
+
+	b.step(fmt.Sprintf("restore campaign %d (%s)", id, before.Name), func() error {
+		_, err := b.campaigns.Update(id, restore)
+		return err
+	})
+	return nil
+}
+
+func (b *builder) revertAdGroup(campaignID, adGroupID int64) error {
+	var changed models.AdGroupUpdate
+	var before models.AdGroup
+	if err := b.decode(&changed, &before); err != nil {
+		return err
+	}
+
+	restore := &models.AdGroupUpdate{}
+	if changed.Name != "" {
+		restore.Name = before.Name
+	}
+	if changed.Status != "" {
+		restore.Status = before.Status
+	}
+	if changed.DefaultBidAmount != nil {
+		restore.DefaultBidAmount = before.DefaultBidAmount
+	}
+	if changed.CpaGoal != nil {
+		if before.CpaGoal == nil {
+			b.irreversible("ad group %d had no CPA goal; it cannot be cleared", adGroupID)
+		}
+		restore.CpaGoal = before.CpaGoal
+	}
+	if changed.AutomatedKeywordsOptIn != nil {
+		optIn := before.AutomatedKeywordsOptIn
+		restore.AutomatedKeywordsOptIn = &optIn
+	}
+	if changed.StartTime != "" {
+		restore.StartTime = before.StartTime
+	}
+	if changed.EndTime != "" {
+		if before.EndTime == "" {
+			b.irreversible("ad group %d had no end time; it cannot be cleared", adGroupID)
+		}
+		restore.EndTime = before.EndTime
+	}
+	if changed.TargetingDimensions != nil {
+		restore.TargetingDimensions = before.TargetingDimensions
+		if restore.TargetingDimensions == nil {
+			restore.TargetingDimensions = &models.TargetingDimensions{}
+		}
+	}
+
+	b.step(fmt.Sprintf("restore ad group %d (%s)", adGroupID, before.Name), func() error {
+		_, err := b.adGroups.Update(campaignID, adGroupID, restore)
+		return err
+	})
+	return nil
+}
+
+func (b *builder) revertKeywords(campaignID, adGroupID int64) error {
+	var changed []models.KeywordUpdate
+	var before []models.Keyword
+	if err := b.decode(&changed, &before); err != nil {
+		return err
+	}
+	byID := map[int64]models.Keyword{}
+	for _, kw := range before {
+		byID[kw.ID] = kw
+	}
+
+	var restore []models.KeywordUpdate
+	for _, u := range changed {
+		kw, ok := byID[u.ID]
+		if !ok {
+			b.irreversible("keyword %d was not found before the change", u.ID)
+			continue
+		}
+		r := models.KeywordUpdate{ID: u.ID}
+		if u.Status != "" {
+			r.Status = kw.Status
+		}
+		if u.BidAmount != nil {
+			if kw.BidAmount == nil {
+				b.irreversible("keyword %d (%s) used the ad group default bid; its bid cannot be cleared", kw.ID, kw.Text)
+			}
+			r.BidAmount = kw.BidAmount
+		}
+		if r.Status != "" || r.BidAmount != nil {
+			restore = append(restore, r)
+		}
+	}
+	if len(restore) == 0 {
+		return nil
+	}
+
+	b.step(fmt.Sprintf("restore %d keyword(s) in ad group %d", len(restore), adGroupID), func() error {
+		_, err := b.keywords.Update(campaignID, adGroupID, restore)
+		return err
+	})
+	return nil
+}
+
+// revertNegatives restores the status of updated negative keywords at
+// campaign level, or ad group level when adGroupID is set.
+func (b *builder) revertNegatives(campaignID, adGroupID int64) error {
+	var changed []models.NegativeKeywordUpdate
+	var before []models.NegativeKeyword
+	if err := b.decode(&changed, &before); err != nil {
+		return err
+	}
+	byID := map[int64]models.NegativeKeyword{}
+	for _, kw := range before {
+		byID[kw.ID] = kw
+	}
+
+	var restore []models.NegativeKeywordUpdate
+	for _, u := range changed {
+		kw, ok := byID[u.ID]
+		if !ok {
+			b.irreversible("negative keyword %d was not found before the change", u.ID)
+			continue
+		}
+		if u.Status != "" {
+			restore = append(restore, models.NegativeKeywordUpdate{ID: u.ID, Status: kw.Status})
+		}
+	}
+	if len(restore) == 0 {
+		return nil
+	}
+
+	if adGroupID == 0 {
+		b.step(fmt.Sprintf("restore %d negative keyword(s) in campaign %d", len(restore), campaignID), func() error {
+			_, err := b.keywords.UpdateCampaignNegativeKeywords(campaignID, restore)
+			return err
+		})
+		return nil
+	}
+	b.step(fmt.Sprintf("restore %d negative keyword(s) in ad group %d", len(restore), adGroupID), func() error {
+		_, err := b.keywords.UpdateAdGroupNegativeKeywords(campaignID, adGroupID, restore)
+		return err
+	})
+	return nil
+}
+
+func (b *builder) recreateKeywords(campaignID, adGroupID int64) error {
+	var deleted []int64
+	var before []models.Keyword
+	if err := b.decode(&deleted, &before); err != nil {
+		return err
+	}
+	if len(before) == 0 {
+		return nil
+	}
+
+	recreate := make([]models.Keyword, len(before))
+	for i, kw := range before {
+		recreate[i] = models.Keyword{Text: kw.Text, MatchType: kw.MatchType, Status: kw.Status, BidAmount: kw.BidAmount}
+	}
+	b.step(fmt.Sprintf("recreate %d deleted keyword(s) in ad group %d (they get new IDs)", len(recreate), adGroupID), func() error {
+		_, err := b.keywords.Create(campaignID, adGroupID, recreate)
+		return err
+	})
+	return nil
+}
+
+// recreateNegatives restores deleted negative keywords at campaign level, or
+// ad group level when adGroupID is set.
+func (b *builder) recreateNegatives(campaignID, adGroupID int64) error {
+	var deleted []int64
+	var before []models.NegativeKeyword
+	if err := b.decode(&deleted, &before); err != nil {
+		return err
+	}
+	if len(before) == 0 {
+		return nil
+	}
+
+	recreate := make([]models.NegativeKeyword, len(before))
+	for i, kw := range before {
+		recreate[i] = models.NegativeKeyword{Text: kw.Text, MatchType: kw.MatchType, Status: kw.Status}
+	}
+	if adGroupID == 0 {
+		b.step(fmt.Sprintf("recreate %d deleted negative keyword(s) in campaign %d (they get new IDs)", len(recreate), campaignID), func() error {
+			_, err := b.keywords.CreateCampaignNegativeKeywords(campaignID, recreate)
+			return err
+		})
+		return nil
+	}
+	b.step(fmt.Sprintf("recreate %d deleted negative keyword(s) in ad group %d (they get new IDs)", len(recreate), adGroupID), func() error {
+		_, err := b.keywords.CreateAdGroupNegativeKeywords(campaignID, adGroupID, recreate)
+		return err
+	})
+	return nil
+}
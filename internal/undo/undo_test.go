@@ -0,0 +1,139 @@
+package undo
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/trebuhs/asa-cli/internal/api"
+	"github.com/trebuhs/asa-cli/internal/audit"
+)
+
+func TestBuild(t *testing.T) {
+	tests := []struct {
+		name             string
+		entry            audit.Entry
+		wantSteps        []string
+		wantIrreversible []string
+		// wantRequests are the method, path and body of each request the
+		// plan sends.
+		wantRequests []string
+		wantErr      string
+	}{
+		{
+			name: "campaign update restores only the changed fields",
+			entry: audit.Entry{
+				Method:  "PUT",
+				Path:    "/campaigns/1",
+				Request: json.RawMessage(`{"campaign":{"dailyBudgetAmount":{"amount":"80","currency":"USD"}}}`),
+				Before:  json.RawMessage(`{"id":1,"name":"Brand","status":"ENABLED","dailyBudgetAmount":{"amount":"50","currency":"USD"}}`),
+			},
+			wantSteps:    []string{"restore campaign 1 (Brand)"},
+			wantRequests: []string{`PUT /campaigns/1 {"campaign":{"dailyBudgetAmount":{"amount":"50","currency":"USD"}}}`},
+		},
+		{
+			name: "ad group CPA goal that was not set",
+			entry: audit.Entry{
+				Method:  "PUT",
+				Path:    "/campaigns/1/adgroups/2",
+				Request: json.RawMessage(`{"cpaGoal":{"amount":"5","currency":"USD"},"status":"PAUSED"}`),
+				Before:  json.RawMessage(`{"id":2,"name":"Exact","status":"ENABLED"}`),
+			},
+			wantSteps:        []string{"restore ad group 2 (Exact)"},
+			wantIrreversible: []string{"ad group 2 had no CPA goal; it cannot be cleared"},
+			wantRequests:     []string{`PUT /campaigns/1/adgroups/2 {"status":"ENABLED"}`},
+		},
+		{
+			name: "keyword bids",
+			entry: audit.Entry{
+				Method:  "PUT",
+				Path:    "/campaigns/1/adgroups/2/targetingkeywords/bulk",
+				Request: json.RawMessage(`[{"id":3,"bidAmount":{"amount":"2","currency":"USD"}},{"id":4,"status":"PAUSED"}]`),
+				Before:  json.RawMessage(`[{"id":3,"text":"habit tracker","bidAmount":{"amount":"1","currency":"USD"}}]`),
+			},
+			wantSteps:        []string{"restore 1 keyword(s) in ad group 2"},
+			wantIrreversible: []string{"keyword 4 was not found before the change"},
+			wantRequests:     []string{`PUT /campaigns/1/adgroups/2/targetingkeywords/bulk [{"id":3,"bidAmount":{"amount":"1","currency":"USD"}}]`},
+		},
+		{
+			name: "deleted ad group negatives are recreated",
+			entry: audit.Entry{
+				Method:  "POST",
+				Path:    "/campaigns/1/adgroups/2/negativekeywords/delete/bulk",
+				Request: json.RawMessage(`[5]`),
+				Before:  json.RawMessage(`[{"id":5,"text":"free","matchType":"EXACT","status":"ACTIVE"}]`),
+			},
+			wantSteps:    []string{"recreate 1 deleted negative keyword(s) in ad group 2 (they get new IDs)"},
+			wantRequests: []string{`POST /campaigns/1/adgroups/2/negativekeywords/bulk [{"text":"free","matchType":"EXACT","status":"ACTIVE"}]`},
+		},
+		{
+			name:         "created keywords are deleted",
+			entry:        audit.Entry{Method: "POST", Path: "/campaigns/1/adgroups/2/targetingkeywords/bulk", EntityIDs: []int64{6, 7}},
+			wantSteps:    []string{"delete 2 created keyword(s) [6 7]"},
+			wantRequests: []string{`POST /campaigns/1/adgroups/2/targetingkeywords/delete/bulk [6,7]`},
+		},
+		{
+			name:             "deleted campaign",
+			entry:            audit.Entry{Method: "DELETE", Path: "/campaigns/1"},
+			wantIrreversible: []string{"campaign 1 was deleted; deleted campaigns cannot be restored"},
+		},
+		{
+			name:    "no snapshot",
+			entry:   audit.Entry{ID: "abc", Method: "PUT", Path: "/campaigns/1", Request: json.RawMessage(`{"campaign":{"status":"PAUSED"}}`)},
+			wantErr: "change abc has no pre-change snapshot",
+		},
+		{
+			name:    "failed change",
+			entry:   audit.Entry{ID: "abc", Method: "PUT", Path: "/campaigns/1", Error: "HTTP 400"},
+			wantErr: "nothing to undo",
+		},
+		{
+			name:    "unsupported path",
+			entry:   audit.Entry{Method: "POST", Path: "/budgetorders"},
+			wantErr: "undo of POST /budgetorders is not supported",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := api.NewClient(nil)
+			client.BaseURL = ""
+			client.DryRun = true
+
+			p, err := Build(client, &tt.entry)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Build() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Build: %v", err)
+			}
+
+			var steps []string
+			for _, s := range p.Steps {
+				steps = append(steps, s.Description)
+			}
+			if !reflect.DeepEqual(steps, tt.wantSteps) {
+				t.Errorf("Steps = %q, want %q", steps, tt.wantSteps)
+			}
+			if !reflect.DeepEqual(p.Irreversible, tt.wantIrreversible) {
+				t.Errorf("Irreversible = %q, want %q", p.Irreversible, tt.wantIrreversible)
+			}
+
+			if err := p.Run(); err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+			var requests []string
+			for _, r := range client.Planned {
+				body, _ := json.Marshal(r.Body)
+				requests = append(requests, r.Method+" "+r.URL+" "+string(body))
+			}
+			if !reflect.DeepEqual(requests, tt.wantRequests) {
+				t.Errorf("requests =\n%s\nwant\n%s", strings.Join(requests, "\n"), strings.Join(tt.wantRequests, "\n"))
+			}
+		})
+	}
+}